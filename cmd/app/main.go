@@ -3,12 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// встраиваем базу часовых поясов: в alpine-образе её нет
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -47,21 +48,36 @@ func main() {
 	teamRepo := postgres.NewTeamRepo(logger)
 	userRepo := postgres.NewUserRepo(logger)
 	prRepo := postgres.NewPRRepo(logger)
+	codeOwnerRepo := postgres.NewCodeOwnerRepo(logger)
+	lockRepo := postgres.NewLockRepo(logger)
 
-	randSrc := rand.New(rand.NewSource(time.Now().UnixNano()))
+	selectors, err := usecase.NewReviewerSelectors(cfg.ReviewerStrategy, cfg.TeamReviewerStrategies)
+	if err != nil {
+		logger.Error("reviewer_selectors_init_failed", "err", err)
+		os.Exit(1)
+	}
 
 	teamSvc := usecase.NewTeamService(teamRepo, userRepo, txManager, logger)
-	userSvc := usecase.NewUserService(userRepo, prRepo, logger)
-	prSvc := usecase.NewPRService(prRepo, userRepo, teamRepo, txManager, randSrc, logger)
+	userSvc := usecase.NewUserService(userRepo, prRepo, teamRepo, logger)
+	prSvc := usecase.NewPRService(prRepo, userRepo, teamRepo, codeOwnerRepo, txManager, selectors, logger)
 	statsSvc := usecase.NewStatsService(prRepo, logger)
+	codeOwnerSvc := usecase.NewCodeOwnerService(codeOwnerRepo, teamRepo, userRepo, txManager, logger)
+
+	staleWorker := usecase.NewStaleReviewWorker(prSvc, lockRepo, txManager, usecase.StaleReviewConfig{
+		Interval: cfg.StaleReviewInterval,
+		DryRun:   cfg.StaleReviewDryRun,
+	}, logger)
+	go staleWorker.Run(ctx)
 
 	apiServer := httpapi.NewServer(
 		teamSvc,
 		userSvc,
 		prSvc,
 		statsSvc,
-		pool, 
+		codeOwnerSvc,
+		pool,
 		logger,
+		cfg.AdminTokens,
 	)
 
 	r := chi.NewRouter()
@@ -116,7 +132,6 @@ func openDB(ctx context.Context, cfg *config.Config, logger log.Logger) (*pgxpoo
 	return pool, nil
 }
 
-
 func requestLoggerMiddleware(logger log.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
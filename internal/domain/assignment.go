@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+type AssignmentKind string
+
+const (
+	AssignmentKindCreate   AssignmentKind = "CREATE"
+	AssignmentKindReassign AssignmentKind = "REASSIGN"
+)
+
+// AssignmentExplanation — запись об одном выборе ревьюверов: кого рассматривали,
+// кого и почему исключили, какой стратегией и с каким seed выбирали.
+// По Rounds и Seed выбор можно повторить и получить тот же результат.
+type AssignmentExplanation struct {
+	ID       int64
+	PRID     string
+	Kind     AssignmentKind
+	Strategy string
+	Seed     int64
+	// Reviewers — выбранные ревьюверы в порядке выбора.
+	Reviewers []string
+	// ReplacedReviewer — заменяемый ревьювер (только для REASSIGN).
+	ReplacedReviewer string
+	Rounds           []SelectionRound
+	Excluded         []SkippedReviewer
+	Reason           string
+	CreatedAt        time.Time
+}
+
+// SelectionRound — один вызов стратегии: кандидаты с их нагрузкой на момент выбора
+// и результат. Раунды выполняются по порядку с общим генератором случайных чисел.
+type SelectionRound struct {
+	Note       string
+	Count      int
+	Assigned   []string
+	Candidates []CandidateSnapshot
+	Picked     []string
+}
+
+type CandidateSnapshot struct {
+	UserID         string
+	OpenReviews    int
+	LastAssignedAt *time.Time
+	// PairScore — история пар с автором (только для стратегии pair_rotation).
+	PairScore float64
+	// Weight — ReviewWeight кандидата на момент выбора (для стратегии weighted).
+	Weight int
+}
+
+// Picked возвращает всех выбранных во всех раундах в порядке выбора.
+func (e AssignmentExplanation) Picked() []string {
+	var out []string
+	for _, r := range e.Rounds {
+		out = append(out, r.Picked...)
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+type CodeOwnerSource string
+
+const (
+	// CodeOwnerSourceManual — правило добавлено через API.
+	CodeOwnerSourceManual CodeOwnerSource = "manual"
+	// CodeOwnerSourceCodeOwners — правило импортировано из файла CODEOWNERS.
+	CodeOwnerSourceCodeOwners CodeOwnerSource = "codeowners"
+)
+
+// CodeOwnerRule — правило в стиле CODEOWNERS: glob-шаблон пути -> команды и пользователи.
+// Правило без владельцев снимает владение с путей, совпавших с более ранними правилами.
+type CodeOwnerRule struct {
+	ID      int64
+	Pattern string
+	Teams   []string
+	Users   []string
+	Source  CodeOwnerSource
+}
+
+func NewCodeOwnerRule(pattern string, teams, users []string, source CodeOwnerSource) (*CodeOwnerRule, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, invalidArgument("code owner pattern is empty")
+	}
+	for _, seg := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return nil, invalidArgument("bad code owner pattern %q: %v", pattern, err)
+		}
+	}
+	if source != CodeOwnerSourceManual && source != CodeOwnerSourceCodeOwners {
+		return nil, invalidArgument("unknown code owner source %q", source)
+	}
+
+	return &CodeOwnerRule{
+		Pattern: pattern,
+		Teams:   dedupStrings(teams),
+		Users:   dedupStrings(users),
+		Source:  source,
+	}, nil
+}
+
+func (r CodeOwnerRule) HasOwners() bool {
+	return len(r.Teams) > 0 || len(r.Users) > 0
+}
+
+func (r CodeOwnerRule) Matches(filePath string) bool {
+	return MatchCodeOwnerPattern(r.Pattern, filePath)
+}
+
+// CodeOwnerMatch — правило и изменённые файлы, для которых оно оказалось решающим.
+type CodeOwnerMatch struct {
+	Rule  CodeOwnerRule
+	Paths []string
+}
+
+// MatchCodeOwners для каждого файла находит последнее совпавшее правило (как в CODEOWNERS)
+// и группирует файлы по правилам. Правила без владельцев в результат не попадают.
+func MatchCodeOwners(rules []CodeOwnerRule, files []string) []CodeOwnerMatch {
+	byRule := make(map[int][]string)
+	for _, f := range files {
+		f = NormalizePath(f)
+		if f == "" {
+			continue
+		}
+		for i := len(rules) - 1; i >= 0; i-- {
+			if rules[i].Matches(f) {
+				byRule[i] = append(byRule[i], f)
+				break
+			}
+		}
+	}
+
+	var out []CodeOwnerMatch
+	for i, rule := range rules {
+		paths, ok := byRule[i]
+		if !ok || !rule.HasOwners() {
+			continue
+		}
+		out = append(out, CodeOwnerMatch{Rule: rule, Paths: paths})
+	}
+	return out
+}
+
+// NormalizePath приводит путь файла к виду "dir/file.go" без ведущих "/" и "./".
+func NormalizePath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	p = path.Clean("/" + p)
+	return strings.TrimPrefix(p, "/")
+}
+
+// MatchCodeOwnerPattern сопоставляет путь с шаблоном по правилам CODEOWNERS:
+//   - шаблон с "/" в начале или в середине привязан к корню репозитория,
+//     без "/" — совпадает на любой глубине;
+//   - "/" в конце означает каталог и всё его содержимое;
+//   - "*" и "?" не пересекают "/", "**" совпадает с любым числом каталогов;
+//   - шаблон, совпавший с каталогом, покрывает все файлы внутри него.
+func MatchCodeOwnerPattern(pattern, filePath string) bool {
+	pattern = strings.TrimSpace(pattern)
+	filePath = NormalizePath(filePath)
+	if pattern == "" || filePath == "" {
+		return false
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return !dirOnly || strings.Contains(filePath, "/")
+	}
+
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+	segs := strings.Split(trimmed, "/")
+	if !anchored {
+		segs = append([]string{"**"}, segs...)
+	}
+
+	return matchSegments(segs, strings.Split(filePath, "/"), dirOnly)
+}
+
+func matchSegments(pattern, segs []string, dirOnly bool) bool {
+	if len(pattern) == 0 {
+		// Шаблон исчерпан: либо совпал весь путь, либо каталог, содержащий файл.
+		return !dirOnly || len(segs) > 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pattern[1:], segs[i:], dirOnly) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segs) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], segs[0])
+	if err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segs[1:], dirOnly)
+}
+
+// ParseCodeOwners разбирает файл CODEOWNERS. Владелец "@org/team" становится командой
+// "team", "@user" — пользователем "user". Строки и владельцы, которые не удалось
+// разобрать (e-mail, секции GitLab, битые шаблоны), пропускаются и попадают в warnings.
+func ParseCodeOwners(content string) (rules []CodeOwnerRule, warnings []string) {
+	for i, line := range strings.Split(content, "\n") {
+		lineNo := i + 1
+
+		if idx := strings.Index(line, "#"); idx >= 0 && (idx == 0 || line[idx-1] != '\\') {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(strings.ReplaceAll(line, `\#`, "#"))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			warnings = append(warnings, fmt.Sprintf("line %d: sections are not supported", lineNo))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var teams, users []string
+		for _, owner := range fields[1:] {
+			name, ok := strings.CutPrefix(owner, "@")
+			if !ok || name == "" {
+				warnings = append(warnings, fmt.Sprintf("line %d: unsupported owner %q", lineNo, owner))
+				continue
+			}
+			if _, team, isTeam := strings.Cut(name, "/"); isTeam {
+				teams = append(teams, team)
+				continue
+			}
+			users = append(users, name)
+		}
+
+		rule, err := NewCodeOwnerRule(fields[0], teams, users, CodeOwnerSourceCodeOwners)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("line %d: %v", lineNo, err))
+			continue
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, warnings
+}
+
+func dedupStrings(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if _, exists := seen[s]; exists {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCodeOwnerPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "main.go", true},
+		{"*.go", "cmd/app/main.go", true},
+		{"*.go", "README.md", false},
+		{"/docs/", "docs/api/openapi.yml", true},
+		{"/docs/", "pkg/docs/readme.md", false},
+		{"/docs/", "docs", false},
+		{"docs/", "pkg/docs/readme.md", true},
+		{"/internal/domain", "internal/domain/pr.go", true},
+		{"internal/domain", "pkg/internal/domain/pr.go", false},
+		{"/cmd/*.go", "cmd/main.go", true},
+		{"/cmd/*.go", "cmd/app/main.go", false},
+		{"/cmd/**/*.go", "cmd/app/main.go", true},
+		{"**/migrations", "db/migrations/0001_init.up.sql", true},
+		{"/internal/**/repo_test.go", "internal/repo_test.go", true},
+		{"Makefile", "tools/Makefile", true},
+		{"/Makefile", "tools/Makefile", false},
+		{"/", "any/file.txt", true},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.want, MatchCodeOwnerPattern(tc.pattern, tc.path), "pattern %q path %q", tc.pattern, tc.path)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	require.Equal(t, "internal/domain/pr.go", NormalizePath(" ./internal//domain/pr.go"))
+	require.Equal(t, "main.go", NormalizePath("/main.go"))
+	require.Equal(t, "", NormalizePath("  "))
+}
+
+func TestNewCodeOwnerRule_Validation(t *testing.T) {
+	_, err := NewCodeOwnerRule(" ", []string{"backend"}, nil, CodeOwnerSourceManual)
+	require.Error(t, err)
+
+	_, err = NewCodeOwnerRule("/src/[a", []string{"backend"}, nil, CodeOwnerSourceManual)
+	de, ok := AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeInvalidArgument, de.Code)
+
+	rule, err := NewCodeOwnerRule("/lib/", []string{"platform", "platform"}, []string{"u1"}, CodeOwnerSourceManual)
+	require.NoError(t, err)
+	require.Equal(t, []string{"platform"}, rule.Teams)
+	require.True(t, rule.HasOwners())
+}
+
+func TestMatchCodeOwners_LastRuleWins(t *testing.T) {
+	rules := []CodeOwnerRule{
+		{Pattern: "*", Teams: []string{"backend"}},
+		{Pattern: "/lib/", Teams: []string{"platform"}},
+		{Pattern: "/lib/generated/"},
+		{Pattern: "*.md", Users: []string{"writer"}},
+	}
+
+	got := MatchCodeOwners(rules, []string{
+		"cmd/main.go",
+		"lib/http/client.go",
+		"lib/generated/api.go",
+		"lib/README.md",
+	})
+
+	require.Len(t, got, 3)
+	require.Equal(t, "*", got[0].Rule.Pattern)
+	require.Equal(t, []string{"cmd/main.go"}, got[0].Paths)
+	require.Equal(t, "/lib/", got[1].Rule.Pattern)
+	require.Equal(t, []string{"lib/http/client.go"}, got[1].Paths)
+	require.Equal(t, "*.md", got[2].Rule.Pattern)
+	require.Equal(t, []string{"lib/README.md"}, got[2].Paths)
+}
+
+func TestParseCodeOwners(t *testing.T) {
+	content := `
+# comment
+*            @acme/backend
+/lib/        @acme/platform @alice   # inline comment
+/docs/       docs@example.com @bob
+/generated/
+[Section]
+/bad/[a      @acme/backend
+`
+
+	rules, warnings := ParseCodeOwners(content)
+
+	require.Len(t, rules, 4)
+	require.Equal(t, []string{"backend"}, rules[0].Teams)
+	require.Equal(t, []string{"platform"}, rules[1].Teams)
+	require.Equal(t, []string{"alice"}, rules[1].Users)
+	require.Equal(t, []string{"bob"}, rules[2].Users)
+	require.False(t, rules[3].HasOwners())
+	for _, r := range rules {
+		require.Equal(t, CodeOwnerSourceCodeOwners, r.Source)
+	}
+	require.Len(t, warnings, 3)
+}
@@ -14,6 +14,14 @@ const (
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	// ErrorCodeInvalidArgument — входные данные нарушают доменные ограничения.
+	ErrorCodeInvalidArgument ErrorCode = "INVALID_ARGUMENT"
+	// ErrorCodeSeniorityUnsatisfied — состав ревьюверов не удовлетворяет политике уровней команды.
+	ErrorCodeSeniorityUnsatisfied ErrorCode = "SENIORITY_POLICY_UNSATISFIED"
+	// ErrorCodeMergeBlocked — PR не удовлетворяет политике merge команды автора.
+	ErrorCodeMergeBlocked ErrorCode = "MERGE_BLOCKED"
+	// ErrorCodeInvalidPRStatus — операция недопустима в текущем статусе PR (DRAFT, CLOSED).
+	ErrorCodeInvalidPRStatus ErrorCode = "INVALID_PR_STATUS"
 )
 
 type DomainError struct {
@@ -43,6 +51,10 @@ func NewDomainError(code ErrorCode, msg string) error {
 	}
 }
 
+func invalidArgument(format string, args ...any) error {
+	return NewDomainError(ErrorCodeInvalidArgument, fmt.Sprintf(format, args...))
+}
+
 func AsDomainError(err error) (*DomainError, bool) {
 	var de *DomainError
 	if errors.As(err, &de) {
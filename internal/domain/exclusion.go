@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxExclusionReasonLen — ограничение длины причины исключения.
+const MaxExclusionReasonLen = 256
+
+// ReviewerExclusion — пара пользователей, которые не ревьюят PR друг друга
+// (руководитель и подчинённый, парное программирование). Пара симметрична:
+// UserID всегда меньше OtherUserID.
+type ReviewerExclusion struct {
+	UserID      string
+	OtherUserID string
+	Reason      string
+	CreatedAt   time.Time
+}
+
+func NewReviewerExclusion(userID, otherUserID, reason string) (*ReviewerExclusion, error) {
+	if userID == "" || otherUserID == "" {
+		return nil, invalidArgument("both user ids are required")
+	}
+	if userID == otherUserID {
+		return nil, invalidArgument("user cannot be excluded from reviewing themselves")
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > MaxExclusionReasonLen {
+		return nil, invalidArgument("exclusion reason is longer than %d bytes", MaxExclusionReasonLen)
+	}
+
+	if otherUserID < userID {
+		userID, otherUserID = otherUserID, userID
+	}
+	return &ReviewerExclusion{UserID: userID, OtherUserID: otherUserID, Reason: reason}, nil
+}
+
+// Other возвращает второго участника пары для userID.
+func (e ReviewerExclusion) Other(userID string) string {
+	if e.UserID == userID {
+		return e.OtherUserID
+	}
+	return e.UserID
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReviewerExclusion(t *testing.T) {
+	e, err := NewReviewerExclusion("u2", "u1", " manager ")
+	require.NoError(t, err)
+	require.Equal(t, "u1", e.UserID)
+	require.Equal(t, "u2", e.OtherUserID)
+	require.Equal(t, "manager", e.Reason)
+	require.Equal(t, "u1", e.Other("u2"))
+	require.Equal(t, "u2", e.Other("u1"))
+
+	_, err = NewReviewerExclusion("u1", "u1", "")
+	require.Error(t, err)
+
+	_, err = NewReviewerExclusion("", "u1", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	MaxLabelLen = 64
+	MaxPRLabels = 20
+)
+
+// NormalizeLabel приводит метку к нижнему регистру без пробелов по краям.
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// NormalizeLabels нормализует метки, убирает пустые и повторы и сортирует.
+func NormalizeLabels(labels []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(labels))
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		l = NormalizeLabel(l)
+		if l == "" {
+			continue
+		}
+		if _, exists := seen[l]; exists {
+			continue
+		}
+		if err := validateLabel(l); err != nil {
+			return nil, err
+		}
+		seen[l] = struct{}{}
+		out = append(out, l)
+	}
+	if len(out) > MaxPRLabels {
+		return nil, invalidArgument("at most %d labels allowed, got %d", MaxPRLabels, len(out))
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func validateLabel(l string) error {
+	if len(l) > MaxLabelLen {
+		return invalidArgument("label %q is longer than %d bytes", l, MaxLabelLen)
+	}
+	if strings.ContainsAny(l, ", \t\n") {
+		return invalidArgument("label %q must not contain spaces or commas", l)
+	}
+	return nil
+}
+
+// SetLabels заменяет метки PR.
+func (p *PullRequest) SetLabels(labels []string) error {
+	normalized, err := NormalizeLabels(labels)
+	if err != nil {
+		return err
+	}
+	p.Labels = normalized
+	return nil
+}
+
+func (p *PullRequest) HasLabel(label string) bool {
+	return slices.Contains(p.Labels, NormalizeLabel(label))
+}
+
+// LabelRule — как метка PR меняет назначение ревьюверов в команде автора.
+type LabelRule struct {
+	Label string
+	// ReviewersCount — сколько ревьюверов нужно PR с меткой вместо TeamSettings.ReviewersCount;
+	// 0 — не меняется. При нескольких метках берётся наибольшее.
+	ReviewersCount int
+	// RequireTeam — команда, из которой на PR с меткой назначается ещё один ревьювер.
+	RequireTeam string
+}
+
+// LabelTeam — команда, ревьювер из которой нужен PR из-за метки Label.
+type LabelTeam struct {
+	Label string
+	Team  string
+}
+
+func validateLabelRules(rules []LabelRule) error {
+	seen := make(map[string]struct{}, len(rules))
+	for _, r := range rules {
+		if r.Label == "" {
+			return invalidArgument("empty label in label rule")
+		}
+		if err := validateLabel(r.Label); err != nil {
+			return err
+		}
+		if _, exists := seen[r.Label]; exists {
+			return invalidArgument("duplicate label rule: %s", r.Label)
+		}
+		seen[r.Label] = struct{}{}
+
+		if r.ReviewersCount < 0 || r.ReviewersCount > MaxReviewersCount {
+			return invalidArgument("label %s: reviewers count must be in [0, %d], got %d", r.Label, MaxReviewersCount, r.ReviewersCount)
+		}
+		if r.ReviewersCount == 0 && r.RequireTeam == "" {
+			return invalidArgument("label %s: rule has no effect", r.Label)
+		}
+	}
+	return nil
+}
+
+// normalizeLabelRules нормализует метки и имена команд в правилах.
+func normalizeLabelRules(rules []LabelRule) []LabelRule {
+	out := make([]LabelRule, 0, len(rules))
+	for _, r := range rules {
+		r.Label = NormalizeLabel(r.Label)
+		r.RequireTeam = strings.TrimSpace(r.RequireTeam)
+		out = append(out, r)
+	}
+	return out
+}
+
+// LabelRulesFor возвращает правила, сработавшие для меток PR, в порядке правил.
+func (s TeamSettings) LabelRulesFor(labels []string) []LabelRule {
+	var out []LabelRule
+	for _, r := range s.LabelRules {
+		if slices.Contains(labels, r.Label) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// LabelTeams возвращает команды, из которых метки PR требуют по ревьюверу (без повторов).
+func (s TeamSettings) LabelTeams(labels []string) []LabelTeam {
+	var out []LabelTeam
+	seen := make(map[string]struct{})
+	for _, r := range s.LabelRulesFor(labels) {
+		if r.RequireTeam == "" {
+			continue
+		}
+		if _, exists := seen[r.RequireTeam]; exists {
+			continue
+		}
+		seen[r.RequireTeam] = struct{}{}
+		out = append(out, LabelTeam{Label: r.Label, Team: r.RequireTeam})
+	}
+	return out
+}
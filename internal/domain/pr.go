@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -10,16 +11,57 @@ type PRStatus string
 const (
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	// PRStatusDraft — PR ещё не готов к ревью, ревьюверы не назначаются.
+	PRStatusDraft PRStatus = "DRAFT"
+	// PRStatusClosed — PR закрыт без merge, ревьюверы сняты.
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
+// prTransitions — допустимые переходы статусов; MERGED — конечный статус.
+var prTransitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusDraft},
+}
+
 type PullRequest struct {
-	ID               string
-	Name             string
-	AuthorID         string
-	Status           PRStatus
+	ID                string
+	Name              string
+	AuthorID          string
+	Status            PRStatus
+	ReviewersRequired int
 	AssignedReviewers []string
+	// FallbackReviewers: id ревьювера -> fallback-команда, из которой он назначен.
+	FallbackReviewers map[string]string
+	RequiredSkills    []string
+	// UncoveredSkills — требуемые навыки, которых нет ни у одного назначенного ревьювера.
+	// Вычисляется при назначении и не хранится.
+	UncoveredSkills []string
+	// ChangedFiles — пути изменённых файлов, по ним выбираются владельцы кода.
+	ChangedFiles []string
+	Metadata     PRMetadata
+	// Labels — метки PR, нормализованы NormalizeLabels.
+	Labels   []string
+	Priority PRPriority
+	// ExcludedReviewers — кого автор попросил не назначать на этот PR (в том числе при переназначении).
+	ExcludedReviewers []string
+	// Reviews: id ревьювера -> его последний вердикт (только для текущих ревьюверов).
+	Reviews map[string]Review
+	// ReviewDueAt: id ревьювера -> срок ревью по SLA команды автора (нет записи — SLA не задан).
+	ReviewDueAt map[string]time.Time
+	// AssignmentReason и SkippedReviewers описывают последний выбор ревьюверов.
+	// Вычисляются при назначении и не хранятся.
+	AssignmentReason string
+	SkippedReviewers []SkippedReviewer
 	CreatedAt        time.Time
 	MergedAt         *time.Time
+	ClosedAt         *time.Time
+}
+
+// SkippedReviewer — кандидат, которого не выбрали, и причина.
+type SkippedReviewer struct {
+	UserID string
+	Reason string
 }
 
 func NewPullRequest(id, name, authorID string) (*PullRequest, error) {
@@ -30,18 +72,89 @@ func NewPullRequest(id, name, authorID string) (*PullRequest, error) {
 	now := time.Now()
 
 	return &PullRequest{
-		ID:               id,
-		Name:             name,
-		AuthorID:         authorID,
-		Status:           PRStatusOpen,
+		ID:                id,
+		Name:              name,
+		AuthorID:          authorID,
+		Status:            PRStatusOpen,
+		ReviewersRequired: DefaultReviewersCount,
+		Priority:          PRPriorityNormal,
 		AssignedReviewers: []string{},
-		CreatedAt:        now,
-		MergedAt:         nil,
+		CreatedAt:         now,
+		MergedAt:          nil,
 	}, nil
 }
 
+// NewDraftPullRequest создаёт PR в статусе DRAFT: ревьюверы назначаются после MarkReady.
+func NewDraftPullRequest(id, name, authorID string) (*PullRequest, error) {
+	pr, err := NewPullRequest(id, name, authorID)
+	if err != nil {
+		return nil, err
+	}
+	pr.Status = PRStatusDraft
+	return pr, nil
+}
+
+func (p *PullRequest) SetReviewersRequired(n int) error {
+	if n < 1 || n > MaxReviewersCount {
+		return invalidArgument("reviewers required must be in [1, %d], got %d", MaxReviewersCount, n)
+	}
+	if len(p.AssignedReviewers) > n {
+		return invalidArgument("already %d reviewers assigned, cannot lower limit to %d", len(p.AssignedReviewers), n)
+	}
+	p.ReviewersRequired = n
+	return nil
+}
+
+func (p *PullRequest) SetRequiredSkills(skills []string) {
+	p.RequiredSkills = NormalizeSkills(skills)
+}
+
+// SetChangedFiles сохраняет изменённые файлы в нормализованном виде (см. NormalizePath).
+func (p *PullRequest) SetChangedFiles(files []string) {
+	seen := make(map[string]struct{}, len(files))
+	out := make([]string, 0, len(files))
+	for _, f := range files {
+		f = NormalizePath(f)
+		if f == "" {
+			continue
+		}
+		if _, exists := seen[f]; exists {
+			continue
+		}
+		seen[f] = struct{}{}
+		out = append(out, f)
+	}
+	p.ChangedFiles = out
+}
+
+// SetExcludedReviewers сохраняет исключённых для PR пользователей без пустых и повторов.
+func (p *PullRequest) SetExcludedReviewers(ids []string) {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	p.ExcludedReviewers = out
+}
+
+// MaxReviewers — лимит слотов; для PR, загруженных без лимита, действует значение по умолчанию.
+func (p *PullRequest) MaxReviewers() int {
+	if p.ReviewersRequired <= 0 {
+		return DefaultReviewersCount
+	}
+	return p.ReviewersRequired
+}
+
 func (p *PullRequest) AssignReviewers(reviewers []string) error {
-	if len(reviewers) > 2 {
+	if len(reviewers) > p.MaxReviewers() {
 		return fmt.Errorf("too many reviewers: %d", len(reviewers))
 	}
 
@@ -81,9 +194,69 @@ func (p *PullRequest) CanModifyReviewers() bool {
 	return p.Status == PRStatusOpen
 }
 
+// EnsureOpen возвращает PR_MERGED для слитого PR и INVALID_PR_STATUS для DRAFT и CLOSED.
+func (p *PullRequest) EnsureOpen(action string) error {
+	switch p.Status {
+	case PRStatusOpen:
+		return nil
+	case PRStatusMerged:
+		return NewDomainError(ErrorCodePRMerged, fmt.Sprintf("cannot %s on merged PR", action))
+	default:
+		return NewDomainError(ErrorCodeInvalidPRStatus, fmt.Sprintf("cannot %s on %s PR", action, p.Status))
+	}
+}
+
+// CanTransition сообщает, допустим ли переход из текущего статуса в to.
+func (p *PullRequest) CanTransition(to PRStatus) bool {
+	for _, s := range prTransitions[p.Status] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PullRequest) transition(to PRStatus) error {
+	if p.CanTransition(to) {
+		p.Status = to
+		return nil
+	}
+	if p.Status == PRStatusMerged {
+		return NewDomainError(ErrorCodePRMerged, fmt.Sprintf("cannot move merged PR to %s", to))
+	}
+	return NewDomainError(ErrorCodeInvalidPRStatus, fmt.Sprintf("cannot move PR from %s to %s", p.Status, to))
+}
+
+// MarkReady переводит DRAFT в OPEN; после этого PR можно назначать ревьюверов.
+func (p *PullRequest) MarkReady() error {
+	return p.transition(PRStatusOpen)
+}
+
+// Close закрывает DRAFT или OPEN PR без merge и снимает всех ревьюверов.
+func (p *PullRequest) Close(at time.Time) error {
+	if err := p.transition(PRStatusClosed); err != nil {
+		return err
+	}
+	p.AssignedReviewers = []string{}
+	p.FallbackReviewers = nil
+	p.Reviews = nil
+	p.ReviewDueAt = nil
+	p.ClosedAt = &at
+	return nil
+}
+
+// Reopen возвращает закрытый PR в DRAFT; ревьюверы будут назначены заново при MarkReady.
+func (p *PullRequest) Reopen() error {
+	if err := p.transition(PRStatusDraft); err != nil {
+		return err
+	}
+	p.ClosedAt = nil
+	return nil
+}
+
 func (p *PullRequest) ReplaceReviewer(oldID, newID string) error {
-	if !p.CanModifyReviewers() {
-		return NewDomainError(ErrorCodePRMerged, "cannot replace reviewers on merged PR")
+	if err := p.EnsureOpen("replace reviewers"); err != nil {
+		return err
 	}
 
 	if newID == "" {
@@ -115,5 +288,68 @@ func (p *PullRequest) ReplaceReviewer(oldID, newID string) error {
 	}
 
 	p.AssignedReviewers[idx] = newID
+	delete(p.FallbackReviewers, oldID)
+	delete(p.Reviews, oldID)
+	delete(p.ReviewDueAt, oldID)
+	return nil
+}
+
+// AddReviewer назначает ревьювера вручную в свободный слот.
+func (p *PullRequest) AddReviewer(userID string) error {
+	if err := p.EnsureOpen("add reviewers"); err != nil {
+		return err
+	}
+	if userID == "" {
+		return invalidArgument("empty reviewer id")
+	}
+	if userID == p.AuthorID {
+		return invalidArgument("author cannot be reviewer")
+	}
+	if p.HasReviewer(userID) {
+		return invalidArgument("reviewer %s is already assigned", userID)
+	}
+	if len(p.AssignedReviewers) >= p.MaxReviewers() {
+		return invalidArgument("all %d reviewer slots are taken", p.MaxReviewers())
+	}
+
+	p.AssignedReviewers = append(p.AssignedReviewers, userID)
+	return nil
+}
+
+// RemoveReviewer снимает ревьювера, освобождая его слот.
+func (p *PullRequest) RemoveReviewer(userID string) error {
+	if err := p.EnsureOpen("remove reviewers"); err != nil {
+		return err
+	}
+
+	for i, id := range p.AssignedReviewers {
+		if id == userID {
+			p.AssignedReviewers = append(p.AssignedReviewers[:i:i], p.AssignedReviewers[i+1:]...)
+			delete(p.FallbackReviewers, userID)
+			delete(p.Reviews, userID)
+			delete(p.ReviewDueAt, userID)
+			return nil
+		}
+	}
+	return NewDomainError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+}
+
+func (p *PullRequest) MarkFallbackReviewer(reviewerID, teamName string) error {
+	if !p.HasReviewer(reviewerID) {
+		return NewDomainError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+	}
+	if p.FallbackReviewers == nil {
+		p.FallbackReviewers = make(map[string]string)
+	}
+	p.FallbackReviewers[reviewerID] = teamName
 	return nil
 }
+
+func (p *PullRequest) HasReviewer(userID string) bool {
+	for _, id := range p.AssignedReviewers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MaxPRDescriptionLen — ограничение длины описания PR.
+const MaxPRDescriptionLen = 16 * 1024
+
+// PRMetadata — необязательные сведения о PR из системы контроля версий.
+type PRMetadata struct {
+	Repository   string
+	SourceBranch string
+	TargetBranch string
+	// URL — ссылка на PR во внешней системе (http или https).
+	URL          string
+	Description  string
+	LinesAdded   int
+	LinesDeleted int
+	FilesChanged int
+}
+
+// ChangedLines — размер PR: добавленные и удалённые строки.
+func (m PRMetadata) ChangedLines() int {
+	return m.LinesAdded + m.LinesDeleted
+}
+
+// SetMetadata проверяет и сохраняет метаданные PR; строки обрезаются по краям.
+func (p *PullRequest) SetMetadata(m PRMetadata) error {
+	m.Repository = strings.TrimSpace(m.Repository)
+	m.SourceBranch = strings.TrimSpace(m.SourceBranch)
+	m.TargetBranch = strings.TrimSpace(m.TargetBranch)
+	m.URL = strings.TrimSpace(m.URL)
+	m.Description = strings.TrimSpace(m.Description)
+
+	if m.LinesAdded < 0 || m.LinesDeleted < 0 || m.FilesChanged < 0 {
+		return invalidArgument("line and file counts must be >= 0")
+	}
+	if m.SourceBranch != "" && m.SourceBranch == m.TargetBranch {
+		return invalidArgument("source and target branch are the same: %s", m.SourceBranch)
+	}
+	if m.URL != "" {
+		u, err := url.Parse(m.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return invalidArgument("bad pull request url %q", m.URL)
+		}
+	}
+	if len(m.Description) > MaxPRDescriptionLen {
+		return invalidArgument("description is longer than %d bytes", MaxPRDescriptionLen)
+	}
+
+	p.Metadata = m
+	return nil
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -167,3 +168,238 @@ func TestReplaceReviewer_NoChangeWhenSameID(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
 }
+
+func TestAssignReviewers_RespectsReviewersRequired(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+	require.Equal(t, DefaultReviewersCount, pr.ReviewersRequired)
+
+	require.NoError(t, pr.SetReviewersRequired(3))
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3", "u4"}))
+
+	err = pr.AssignReviewers([]string{"u2", "u3", "u4", "u5"})
+	require.Error(t, err)
+}
+
+func TestSetReviewersRequired_Invalid(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+
+	require.Error(t, pr.SetReviewersRequired(0))
+	require.Error(t, pr.SetReviewersRequired(MaxReviewersCount+1))
+
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3"}))
+	require.Error(t, pr.SetReviewersRequired(1))
+}
+
+func TestMarkFallbackReviewer(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3"}))
+
+	require.NoError(t, pr.MarkFallbackReviewer("u3", "platform"))
+	require.Equal(t, map[string]string{"u3": "platform"}, pr.FallbackReviewers)
+
+	err = pr.MarkFallbackReviewer("u9", "platform")
+	de, ok := AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeNotAssigned, de.Code)
+
+	require.NoError(t, pr.ReplaceReviewer("u3", "u4"))
+	require.Empty(t, pr.FallbackReviewers)
+}
+
+func TestAddReviewer_Invariants(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+
+	require.Error(t, pr.AddReviewer("u1"))
+	require.NoError(t, pr.AddReviewer("u2"))
+	require.Error(t, pr.AddReviewer("u2"))
+	require.NoError(t, pr.AddReviewer("u3"))
+
+	err = pr.AddReviewer("u4")
+	de, ok := AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeInvalidArgument, de.Code)
+	require.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	pr.MarkMerged()
+	de, ok = AsDomainError(pr.AddReviewer("u4"))
+	require.True(t, ok)
+	require.Equal(t, ErrorCodePRMerged, de.Code)
+}
+
+func TestRemoveReviewer(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3"}))
+	require.NoError(t, pr.MarkFallbackReviewer("u2", "platform"))
+
+	require.NoError(t, pr.RemoveReviewer("u2"))
+	require.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	require.Empty(t, pr.FallbackReviewers)
+
+	de, ok := AsDomainError(pr.RemoveReviewer("u2"))
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeNotAssigned, de.Code)
+}
+
+func TestSubmitReview(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3"}))
+
+	at := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	rv, err := pr.SubmitReview("u2", ReviewVerdictApproved, " lgtm ", at)
+	require.NoError(t, err)
+	require.Equal(t, "lgtm", rv.Message)
+	require.Equal(t, ReviewVerdictApproved, pr.Reviews["u2"].Verdict)
+
+	_, err = pr.SubmitReview("u4", ReviewVerdictApproved, "", at)
+	de, ok := AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeNotAssigned, de.Code)
+
+	_, err = pr.SubmitReview("u3", ReviewVerdict("LGTM"), "", at)
+	de, ok = AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeInvalidArgument, de.Code)
+
+	require.NoError(t, pr.RemoveReviewer("u2"))
+	require.NotContains(t, pr.Reviews, "u2")
+
+	pr.MarkMerged()
+	_, err = pr.SubmitReview("u3", ReviewVerdictCommented, "", at)
+	de, ok = AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodePRMerged, de.Code)
+}
+
+func TestMergeBlockers(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3"}))
+
+	policy := TeamSettings{MinApprovals: 2, BlockOnChangesRequested: true}
+	require.Empty(t, pr.MergeBlockers(TeamSettings{}))
+	require.Equal(t, []string{"2 approval(s) required, have 0"}, pr.MergeBlockers(policy))
+
+	at := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	_, err = pr.SubmitReview("u2", ReviewVerdictApproved, "", at)
+	require.NoError(t, err)
+	_, err = pr.SubmitReview("u3", ReviewVerdictChangesRequested, "", at)
+	require.NoError(t, err)
+	require.Equal(t, 1, pr.Approvals())
+	require.Equal(t, []string{
+		"2 approval(s) required, have 1",
+		"changes requested by u3",
+	}, pr.MergeBlockers(policy))
+
+	policy.BlockOnChangesRequested = false
+	policy.MinApprovals = 1
+	require.Empty(t, pr.MergeBlockers(policy))
+}
+
+func TestPullRequest_StatusTransitions(t *testing.T) {
+	pr, err := NewDraftPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+	require.Equal(t, PRStatusDraft, pr.Status)
+
+	de, ok := AsDomainError(pr.AddReviewer("u2"))
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeInvalidPRStatus, de.Code)
+	require.False(t, pr.CanTransition(PRStatusMerged))
+
+	require.NoError(t, pr.MarkReady())
+	require.Equal(t, PRStatusOpen, pr.Status)
+	de, ok = AsDomainError(pr.MarkReady())
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeInvalidPRStatus, de.Code)
+
+	require.NoError(t, pr.AssignReviewers([]string{"u2", "u3"}))
+	require.NoError(t, pr.MarkFallbackReviewer("u3", "platform"))
+	closedAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, pr.Close(closedAt))
+	require.Equal(t, PRStatusClosed, pr.Status)
+	require.Empty(t, pr.AssignedReviewers)
+	require.Empty(t, pr.FallbackReviewers)
+	require.Equal(t, closedAt, *pr.ClosedAt)
+
+	_, err = pr.SubmitReview("u2", ReviewVerdictApproved, "", closedAt)
+	de, ok = AsDomainError(err)
+	require.True(t, ok)
+	require.Equal(t, ErrorCodeInvalidPRStatus, de.Code)
+
+	require.NoError(t, pr.Reopen())
+	require.Equal(t, PRStatusDraft, pr.Status)
+	require.Nil(t, pr.ClosedAt)
+
+	require.NoError(t, pr.MarkReady())
+	pr.MarkMerged()
+	de, ok = AsDomainError(pr.Close(closedAt))
+	require.True(t, ok)
+	require.Equal(t, ErrorCodePRMerged, de.Code)
+}
+
+func TestSetMetadata_Validation(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+
+	require.Error(t, pr.SetMetadata(PRMetadata{LinesAdded: -1}))
+	require.Error(t, pr.SetMetadata(PRMetadata{SourceBranch: "main", TargetBranch: "main"}))
+	require.Error(t, pr.SetMetadata(PRMetadata{URL: "ftp://git.example.com/pr/1"}))
+	require.Error(t, pr.SetMetadata(PRMetadata{Description: strings.Repeat("x", MaxPRDescriptionLen+1)}))
+	require.Equal(t, PRMetadata{}, pr.Metadata)
+
+	require.NoError(t, pr.SetMetadata(PRMetadata{
+		Repository:   " backend/api ",
+		SourceBranch: "feature/x",
+		TargetBranch: "main",
+		URL:          "https://git.example.com/backend/api/pull/1",
+		LinesAdded:   300,
+		LinesDeleted: 250,
+		FilesChanged: 12,
+	}))
+	require.Equal(t, "backend/api", pr.Metadata.Repository)
+	require.Equal(t, 550, pr.Metadata.ChangedLines())
+}
+
+func TestSetLabels_Normalizes(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Add feature", "u1")
+	require.NoError(t, err)
+
+	require.NoError(t, pr.SetLabels([]string{" Security", "docs", "", "security"}))
+	require.Equal(t, []string{"docs", "security"}, pr.Labels)
+	require.True(t, pr.HasLabel("SECURITY"))
+
+	require.Error(t, pr.SetLabels([]string{"needs review"}))
+	require.Error(t, pr.SetLabels([]string{strings.Repeat("x", MaxLabelLen+1)}))
+	require.Equal(t, []string{"docs", "security"}, pr.Labels)
+}
+
+func TestSetPriority(t *testing.T) {
+	pr, err := NewPullRequest("pr-1", "Fix outage", "u1")
+	require.NoError(t, err)
+	require.Equal(t, PRPriorityNormal, pr.Priority)
+
+	require.NoError(t, pr.SetPriority(" HotFix "))
+	require.Equal(t, PRPriorityHotfix, pr.Priority)
+
+	require.NoError(t, pr.SetPriority(""))
+	require.Equal(t, PRPriorityNormal, pr.Priority)
+
+	require.Error(t, pr.SetPriority("urgent"))
+	require.Equal(t, PRPriorityNormal, pr.Priority)
+
+	require.Greater(t, PRPriorityHotfix.Rank(), PRPriorityHigh.Rank())
+	require.Greater(t, PRPriorityHigh.Rank(), PRPriorityNormal.Rank())
+	require.Greater(t, PRPriorityNormal.Rank(), PRPriorityLow.Rank())
+
+	settings := DefaultTeamSettings()
+	settings.WorkingHoursLookahead = 4
+	hotfix := settings.ForPriority(PRPriorityHotfix)
+	require.True(t, hotfix.PreferWorkingHours)
+	require.Zero(t, hotfix.WorkingHoursLookahead)
+	require.Equal(t, settings, settings.ForPriority(PRPriorityHigh))
+}
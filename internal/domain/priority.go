@@ -0,0 +1,60 @@
+package domain
+
+import "strings"
+
+// PRPriority — срочность PR; от неё зависит порядок очереди ревьювера,
+// а для hotfix — и выбор ревьюверов.
+type PRPriority string
+
+const (
+	PRPriorityLow    PRPriority = "low"
+	PRPriorityNormal PRPriority = "normal"
+	PRPriorityHigh   PRPriority = "high"
+	// PRPriorityHotfix — срочное исправление инцидента.
+	PRPriorityHotfix PRPriority = "hotfix"
+)
+
+// ParsePRPriority нормализует приоритет; пустая строка — normal.
+func ParsePRPriority(s string) (PRPriority, error) {
+	p := PRPriority(strings.ToLower(strings.TrimSpace(s)))
+	switch p {
+	case "":
+		return PRPriorityNormal, nil
+	case PRPriorityLow, PRPriorityNormal, PRPriorityHigh, PRPriorityHotfix:
+		return p, nil
+	}
+	return "", invalidArgument("unknown priority %q, expected low, normal, high or hotfix", s)
+}
+
+// Rank — чем больше, тем срочнее.
+func (p PRPriority) Rank() int {
+	switch p {
+	case PRPriorityLow:
+		return 0
+	case PRPriorityHigh:
+		return 2
+	case PRPriorityHotfix:
+		return 3
+	}
+	return 1
+}
+
+// SetPriority меняет приоритет PR.
+func (p *PullRequest) SetPriority(priority string) error {
+	parsed, err := ParsePRPriority(priority)
+	if err != nil {
+		return err
+	}
+	p.Priority = parsed
+	return nil
+}
+
+// ForPriority возвращает настройки выбора ревьюверов для PR с приоритетом p:
+// на hotfix назначаются только те, у кого сейчас рабочее время, если такие есть.
+func (s TeamSettings) ForPriority(p PRPriority) TeamSettings {
+	if p == PRPriorityHotfix {
+		s.PreferWorkingHours = true
+		s.WorkingHoursLookahead = 0
+	}
+	return s
+}
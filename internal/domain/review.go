@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxReviewMessageLen — ограничение длины комментария к вердикту.
+const MaxReviewMessageLen = 4096
+
+type ReviewVerdict string
+
+const (
+	ReviewVerdictApproved         ReviewVerdict = "APPROVED"
+	ReviewVerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	ReviewVerdictCommented        ReviewVerdict = "COMMENTED"
+)
+
+func ParseReviewVerdict(s string) (ReviewVerdict, error) {
+	v := ReviewVerdict(strings.ToUpper(strings.TrimSpace(s)))
+	switch v {
+	case ReviewVerdictApproved, ReviewVerdictChangesRequested, ReviewVerdictCommented:
+		return v, nil
+	default:
+		return "", invalidArgument("unknown review verdict %q", s)
+	}
+}
+
+// Review — вердикт ревьювера по PR. Учитывается только последний вердикт,
+// отправленный после текущего назначения ревьювера.
+type Review struct {
+	ReviewerID  string
+	Verdict     ReviewVerdict
+	Message     string
+	SubmittedAt time.Time
+}
+
+// SubmitReview записывает вердикт назначенного ревьювера открытого PR.
+func (p *PullRequest) SubmitReview(reviewerID string, verdict ReviewVerdict, message string, at time.Time) (*Review, error) {
+	if err := p.EnsureOpen("submit review"); err != nil {
+		return nil, err
+	}
+	if !p.HasReviewer(reviewerID) {
+		return nil, NewDomainError(ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+	}
+	if _, err := ParseReviewVerdict(string(verdict)); err != nil {
+		return nil, err
+	}
+	message = strings.TrimSpace(message)
+	if len(message) > MaxReviewMessageLen {
+		return nil, invalidArgument("review message is longer than %d bytes", MaxReviewMessageLen)
+	}
+
+	review := Review{ReviewerID: reviewerID, Verdict: verdict, Message: message, SubmittedAt: at}
+	if p.Reviews == nil {
+		p.Reviews = make(map[string]Review)
+	}
+	p.Reviews[reviewerID] = review
+	return &review, nil
+}
+
+// Approvals возвращает число текущих ревьюверов с вердиктом APPROVED.
+func (p *PullRequest) Approvals() int {
+	n := 0
+	for _, id := range p.AssignedReviewers {
+		if rv, ok := p.Reviews[id]; ok && rv.Verdict == ReviewVerdictApproved {
+			n++
+		}
+	}
+	return n
+}
+
+// ChangesRequestedBy возвращает текущих ревьюверов, требующих изменений, в порядке слотов.
+func (p *PullRequest) ChangesRequestedBy() []string {
+	var ids []string
+	for _, id := range p.AssignedReviewers {
+		if rv, ok := p.Reviews[id]; ok && rv.Verdict == ReviewVerdictChangesRequested {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// MergeBlockers перечисляет, чего не хватает для merge по политике s; пусто — merge разрешён.
+func (p *PullRequest) MergeBlockers(s TeamSettings) []string {
+	var blockers []string
+	// Метка может уменьшить число ревьюверов PR ниже MinApprovals.
+	need := s.MinApprovals
+	if p.ReviewersRequired > 0 {
+		need = min(need, p.ReviewersRequired)
+	}
+	if have := p.Approvals(); have < need {
+		blockers = append(blockers, fmt.Sprintf("%d approval(s) required, have %d", need, have))
+	}
+	if s.BlockOnChangesRequested {
+		if ids := p.ChangesRequestedBy(); len(ids) > 0 {
+			blockers = append(blockers, "changes requested by "+strings.Join(ids, ", "))
+		}
+	}
+	return blockers
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "strings"
+
+// Seniority — уровень участника. Пустое значение — уровень не указан:
+// такой участник не считается ни junior, ни senior.
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityMiddle Seniority = "middle"
+	SenioritySenior Seniority = "senior"
+	SeniorityLead   Seniority = "lead"
+)
+
+// ParseSeniority нормализует уровень; пустая строка допустима.
+func ParseSeniority(s string) (Seniority, error) {
+	level := Seniority(strings.ToLower(strings.TrimSpace(s)))
+	switch level {
+	case "", SeniorityJunior, SeniorityMiddle, SenioritySenior, SeniorityLead:
+		return level, nil
+	}
+	return "", invalidArgument("unknown seniority %q, expected junior, middle, senior or lead", s)
+}
+
+// IsSenior — senior и выше.
+func (s Seniority) IsSenior() bool {
+	return s == SenioritySenior || s == SeniorityLead
+}
+
+func (s Seniority) IsJunior() bool {
+	return s == SeniorityJunior
+}
+
+// SeniorityFallback — что делать, если состав ревьюверов не удовлетворяет
+// политике команды (MinSeniorReviewers, MaxJuniorReviewers).
+type SeniorityFallback string
+
+const (
+	// SeniorityFallbackReject — вернуть ошибку SENIORITY_POLICY_UNSATISFIED.
+	SeniorityFallbackReject SeniorityFallback = "REJECT"
+	// SeniorityFallbackIgnore — добрать ревьюверов без учёта политики, с предупреждением в логах.
+	SeniorityFallbackIgnore SeniorityFallback = "IGNORE"
+	// SeniorityFallbackLeaveEmpty — оставить слоты, которые нельзя заполнить по политике, пустыми.
+	SeniorityFallbackLeaveEmpty SeniorityFallback = "LEAVE_EMPTY"
+)
+
+func (f SeniorityFallback) Valid() bool {
+	switch f {
+	case SeniorityFallbackReject, SeniorityFallbackIgnore, SeniorityFallbackLeaveEmpty:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// MaxReviewSLAHours — верхняя граница SLA ревью (в рабочих часах).
+const MaxReviewSLAHours = 24 * 30
+
+// DefaultBusinessHours — рабочее время для SLA у тех, кто не задал своё.
+var DefaultBusinessHours = WorkingHours{Start: 9 * 60, End: 18 * 60}
+
+// AddBusinessHours возвращает момент, когда с start пройдёт d рабочего времени:
+// учитываются только окна wh в зоне loc по будним дням (окно через полночь
+// относится к дню, в который начинается).
+func AddBusinessHours(start time.Time, d time.Duration, wh WorkingHours, loc *time.Location) time.Time {
+	if d <= 0 {
+		return start
+	}
+	local := start.In(loc)
+	// Начинаем с предыдущего дня: его ночное окно могло ещё не закончиться.
+	day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+	cur := start
+
+	// Не больше ~10 лет перебора; при валидных окнах выходим намного раньше.
+	for i := 0; i < 3660; i, day = i+1, day.AddDate(0, 0, 1) {
+		if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			continue
+		}
+		open := time.Date(day.Year(), day.Month(), day.Day(), wh.Start/60, wh.Start%60, 0, 0, loc)
+		closeDay := day
+		if wh.End <= wh.Start {
+			closeDay = day.AddDate(0, 0, 1)
+		}
+		closeAt := time.Date(closeDay.Year(), closeDay.Month(), closeDay.Day(), wh.End/60, wh.End%60, 0, 0, loc)
+		if !closeAt.After(cur) {
+			continue
+		}
+		if open.After(cur) {
+			cur = open
+		}
+		avail := closeAt.Sub(cur)
+		if avail >= d {
+			return cur.Add(d)
+		}
+		d -= avail
+		cur = closeAt
+	}
+	return cur.Add(d)
+}
+
+// ReviewDueAt возвращает срок ревью для reviewer, назначенного в assignedAt, по SLA
+// команды автора; nil — SLA не задан. Считается в рабочем времени ревьювера
+// (или DefaultBusinessHours) в его часовом поясе.
+func (s TeamSettings) ReviewDueAt(reviewer User, assignedAt time.Time) *time.Time {
+	if s.ReviewSLAHours <= 0 {
+		return nil
+	}
+	wh := DefaultBusinessHours
+	if reviewer.WorkingHours != nil {
+		wh = *reviewer.WorkingHours
+	}
+	due := AddBusinessHours(assignedAt, time.Duration(s.ReviewSLAHours)*time.Hour, wh, reviewer.Location())
+	return &due
+}
+
+// SetReviewDueAt запоминает срок ревью текущего ревьювера; nil — срока нет.
+func (p *PullRequest) SetReviewDueAt(reviewerID string, due *time.Time) {
+	if due == nil {
+		delete(p.ReviewDueAt, reviewerID)
+		return
+	}
+	if p.ReviewDueAt == nil {
+		p.ReviewDueAt = make(map[string]time.Time)
+	}
+	p.ReviewDueAt[reviewerID] = *due
+}
+
+// NextReviewDueAt возвращает ближайший срок среди ревьюверов, ещё не оставивших вердикт.
+func (p *PullRequest) NextReviewDueAt() *time.Time {
+	var next *time.Time
+	for _, id := range p.AssignedReviewers {
+		due, ok := p.ReviewDueAt[id]
+		if !ok {
+			continue
+		}
+		if _, reviewed := p.Reviews[id]; reviewed {
+			continue
+		}
+		if next == nil || due.Before(*next) {
+			d := due
+			next = &d
+		}
+	}
+	return next
+}
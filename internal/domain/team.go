@@ -1,10 +1,216 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultReviewersCount = 2
+	MaxReviewersCount     = 10
+	// MaxWorkingHoursLookahead — на сколько часов вперёд можно смотреть, ожидая начала рабочего дня.
+	MaxWorkingHoursLookahead = 24
+	// DefaultPairRotationWindowDays и MaxPairRotationWindowDays — за сколько дней
+	// учитывается история пар автор–ревьювер в стратегии pair_rotation.
+	DefaultPairRotationWindowDays = 30
+	MaxPairRotationWindowDays     = 365
+	// MaxStaleReviewHours — верхняя граница возраста назначения для автопереназначения.
+	MaxStaleReviewHours = 24 * 90
+)
+
+// CapacityPolicy — что делать, если все кандидаты достигли лимита открытых ревью.
+type CapacityPolicy string
+
+const (
+	CapacityPolicyAssignAnyway CapacityPolicy = "ASSIGN_ANYWAY"
+	CapacityPolicyLeaveEmpty   CapacityPolicy = "LEAVE_EMPTY"
+	CapacityPolicyNoCandidate  CapacityPolicy = "NO_CANDIDATE"
+)
+
+func (p CapacityPolicy) Valid() bool {
+	switch p {
+	case CapacityPolicyAssignAnyway, CapacityPolicyLeaveEmpty, CapacityPolicyNoCandidate:
+		return true
+	}
+	return false
+}
+
+type TeamSettings struct {
+	ReviewersCount int
+	// FallbackTeams — команды (в порядке приоритета), из которых добираются
+	// ревьюверы, если в своей команде не хватает кандидатов.
+	FallbackTeams []string
+	// DefaultReviewCapacity — лимит открытых ревью для участников без личного лимита; nil — без лимита.
+	DefaultReviewCapacity *int
+	CapacityPolicy        CapacityPolicy
+	// PreferWorkingHours — сначала выбирать тех, у кого сейчас рабочее время
+	// (или начнётся не позже чем через WorkingHoursLookahead часов).
+	PreferWorkingHours    bool
+	WorkingHoursLookahead int
+	// PairRotationWindowDays — окно затухания истории пар автор–ревьювер (стратегия pair_rotation).
+	PairRotationWindowDays int
+	// MinSeniorReviewers — сколько ревьюверов уровня senior и выше должно быть на PR.
+	MinSeniorReviewers int
+	// MaxJuniorReviewers — сколько junior-ревьюверов допускается на PR; nil — без ограничения.
+	MaxJuniorReviewers *int
+	SeniorityFallback  SeniorityFallback
+	// MinApprovals — сколько вердиктов APPROVED нужно для merge PR авторов команды.
+	MinApprovals int
+	// BlockOnChangesRequested — не давать merge, пока хотя бы один ревьювер требует изменений.
+	BlockOnChangesRequested bool
+	// LargePRLines — порог размера PR (добавленные + удалённые строки), выше которого
+	// назначается на LargePRExtraReviewers ревьюверов больше; 0 — правило выключено.
+	LargePRLines          int
+	LargePRExtraReviewers int
+	// LabelRules — как метки PR меняют число ревьюверов и откуда они назначаются.
+	LabelRules []LabelRule
+	// ReviewSLAHours — за сколько рабочих часов ревьювер должен оставить вердикт; 0 — без SLA.
+	ReviewSLAHours int
+	// StaleReviewHours — через сколько часов без вердикта назначение на PR авторов команды
+	// переназначается фоновым обработчиком; 0 — не переназначается.
+	StaleReviewHours int
+}
+
+func DefaultTeamSettings() TeamSettings {
+	return TeamSettings{
+		ReviewersCount:         DefaultReviewersCount,
+		CapacityPolicy:         CapacityPolicyAssignAnyway,
+		PairRotationWindowDays: DefaultPairRotationWindowDays,
+		SeniorityFallback:      SeniorityFallbackReject,
+		LargePRExtraReviewers:  1,
+	}
+}
+
+// WithDefaults подставляет значения по умолчанию вместо незаданных (нулевых) полей.
+func (s TeamSettings) WithDefaults() TeamSettings {
+	if s.ReviewersCount == 0 {
+		s.ReviewersCount = DefaultReviewersCount
+	}
+	if s.CapacityPolicy == "" {
+		s.CapacityPolicy = CapacityPolicyAssignAnyway
+	}
+	if s.PairRotationWindowDays == 0 {
+		s.PairRotationWindowDays = DefaultPairRotationWindowDays
+	}
+	if s.SeniorityFallback == "" {
+		s.SeniorityFallback = SeniorityFallbackReject
+	}
+	return s
+}
+
+func (s TeamSettings) Validate() error {
+	if s.ReviewersCount < 1 || s.ReviewersCount > MaxReviewersCount {
+		return invalidArgument("reviewers count must be in [1, %d], got %d", MaxReviewersCount, s.ReviewersCount)
+	}
+
+	seen := make(map[string]struct{}, len(s.FallbackTeams))
+	for _, name := range s.FallbackTeams {
+		if name == "" {
+			return invalidArgument("empty fallback team name")
+		}
+		if _, exists := seen[name]; exists {
+			return invalidArgument("duplicate fallback team: %s", name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	if s.DefaultReviewCapacity != nil && *s.DefaultReviewCapacity < 0 {
+		return invalidArgument("default review capacity must be >= 0, got %d", *s.DefaultReviewCapacity)
+	}
+	if !s.CapacityPolicy.Valid() {
+		return invalidArgument("unknown capacity policy %q", s.CapacityPolicy)
+	}
+	if s.WorkingHoursLookahead < 0 || s.WorkingHoursLookahead > MaxWorkingHoursLookahead {
+		return invalidArgument("working hours lookahead must be in [0, %d], got %d", MaxWorkingHoursLookahead, s.WorkingHoursLookahead)
+	}
+	if s.PairRotationWindowDays < 1 || s.PairRotationWindowDays > MaxPairRotationWindowDays {
+		return invalidArgument("pair rotation window must be in [1, %d] days, got %d", MaxPairRotationWindowDays, s.PairRotationWindowDays)
+	}
+	if s.MinSeniorReviewers < 0 || s.MinSeniorReviewers > s.ReviewersCount {
+		return invalidArgument("min senior reviewers must be in [0, %d], got %d", s.ReviewersCount, s.MinSeniorReviewers)
+	}
+	if s.MaxJuniorReviewers != nil && *s.MaxJuniorReviewers < 0 {
+		return invalidArgument("max junior reviewers must be >= 0, got %d", *s.MaxJuniorReviewers)
+	}
+	if !s.SeniorityFallback.Valid() {
+		return invalidArgument("unknown seniority fallback %q", s.SeniorityFallback)
+	}
+	if s.MinApprovals < 0 || s.MinApprovals > s.ReviewersCount {
+		return invalidArgument("min approvals must be in [0, %d], got %d", s.ReviewersCount, s.MinApprovals)
+	}
+	if s.LargePRLines < 0 {
+		return invalidArgument("large PR lines must be >= 0, got %d", s.LargePRLines)
+	}
+	if s.LargePRExtraReviewers < 0 || s.LargePRExtraReviewers > MaxReviewersCount {
+		return invalidArgument("large PR extra reviewers must be in [0, %d], got %d", MaxReviewersCount, s.LargePRExtraReviewers)
+	}
+	if s.ReviewSLAHours < 0 || s.ReviewSLAHours > MaxReviewSLAHours {
+		return invalidArgument("review SLA must be in [0, %d] hours, got %d", MaxReviewSLAHours, s.ReviewSLAHours)
+	}
+	if s.StaleReviewHours < 0 || s.StaleReviewHours > MaxStaleReviewHours {
+		return invalidArgument("stale review age must be in [0, %d] hours, got %d", MaxStaleReviewHours, s.StaleReviewHours)
+	}
+	if err := validateLabelRules(s.LabelRules); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReviewCapacity возвращает действующий лимит открытых ревью участника; ok=false — лимита нет.
+func (s TeamSettings) ReviewCapacity(u User) (capacity int, ok bool) {
+	if u.ReviewCapacity != nil {
+		return *u.ReviewCapacity, true
+	}
+	if s.DefaultReviewCapacity != nil {
+		return *s.DefaultReviewCapacity, true
+	}
+	return 0, false
+}
+
+// OffHours возвращает причину, по которой участник сейчас не в рабочем времени с учётом
+// WorkingHoursLookahead; пустая строка — участник подходит или политика выключена.
+func (s TeamSettings) OffHours(u User, now time.Time) string {
+	if !s.PreferWorkingHours || u.WorkingHours == nil {
+		return ""
+	}
+	wait := u.UntilWorkingHours(now)
+	if wait <= time.Duration(s.WorkingHoursLookahead)*time.Hour {
+		return ""
+	}
+	local := now.In(u.Location())
+	return fmt.Sprintf("outside working hours (%s local time %s, working hours %s)",
+		u.Location(), local.Format("15:04"), u.WorkingHours)
+}
+
+// ReviewersFor возвращает, сколько ревьюверов нужно PR такого размера и с такими метками
+// (не больше MaxReviewersCount): правила меток могут заменить ReviewersCount, и каждая
+// команда из LabelTeams добавляет по ревьюверу.
+func (s TeamSettings) ReviewersFor(m PRMetadata, labels []string) int {
+	n := s.WithDefaults().ReviewersCount
+	override := 0
+	for _, r := range s.LabelRulesFor(labels) {
+		override = max(override, r.ReviewersCount)
+	}
+	if override > 0 {
+		n = override
+	}
+	if s.LargePRLines > 0 && m.ChangedLines() > s.LargePRLines {
+		n += s.LargePRExtraReviewers
+	}
+	n += len(s.LabelTeams(labels))
+	return min(n, MaxReviewersCount)
+}
+
+// PairRotationWindow возвращает окно затухания истории пар автор–ревьювер.
+func (s TeamSettings) PairRotationWindow() time.Duration {
+	return time.Duration(s.WithDefaults().PairRotationWindowDays) * 24 * time.Hour
+}
 
 type Team struct {
-	Name    string
-	Members []User
+	Name     string
+	Members  []User
+	Settings TeamSettings
 }
 
 func NewTeam(name string, members []User) (*Team, error) {
@@ -20,11 +226,31 @@ func NewTeam(name string, members []User) (*Team, error) {
 	}
 
 	return &Team{
-		Name:    name,
-		Members: validatedMembers,
+		Name:     name,
+		Members:  validatedMembers,
+		Settings: DefaultTeamSettings(),
 	}, nil
 }
 
+func (t *Team) SetSettings(settings TeamSettings) error {
+	settings.LabelRules = normalizeLabelRules(settings.LabelRules)
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	for _, name := range settings.FallbackTeams {
+		if name == t.Name {
+			return invalidArgument("team cannot be its own fallback")
+		}
+	}
+	for _, r := range settings.LabelRules {
+		if r.RequireTeam == t.Name {
+			return invalidArgument("label %s: team cannot require a reviewer from itself", r.Label)
+		}
+	}
+	t.Settings = settings
+	return nil
+}
+
 func (t *Team) ActiveMembers() []User {
 	active := make([]User, 0, len(t.Members))
 	for _, m := range t.Members {
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +24,8 @@ func TestNewTeam_FiltersMembersByTeamName(t *testing.T) {
 	for _, m := range team.Members {
 		require.Equal(t, "backend", m.TeamName)
 	}
+
+	require.Equal(t, DefaultTeamSettings(), team.Settings)
 }
 
 func TestNewTeam_EmptyName_Error(t *testing.T) {
@@ -84,3 +87,208 @@ func TestTeam_HasMember(t *testing.T) {
 	require.True(t, team.HasMember("u2"))
 	require.False(t, team.HasMember("u3"))
 }
+
+func TestTeamSettings_Validate(t *testing.T) {
+	settings := DefaultTeamSettings()
+	require.NoError(t, settings.Validate())
+
+	settings.ReviewersCount = 0
+	require.Error(t, settings.Validate())
+
+	settings.ReviewersCount = MaxReviewersCount + 1
+	require.Error(t, settings.Validate())
+}
+
+func TestTeam_SetSettings_FallbackTeams(t *testing.T) {
+	team, err := NewTeam("backend", nil)
+	require.NoError(t, err)
+
+	settings := DefaultTeamSettings()
+	settings.FallbackTeams = []string{"platform", "frontend"}
+	require.NoError(t, team.SetSettings(settings))
+	require.Equal(t, []string{"platform", "frontend"}, team.Settings.FallbackTeams)
+
+	settings.FallbackTeams = []string{"backend"}
+	require.Error(t, team.SetSettings(settings))
+
+	settings.FallbackTeams = []string{"platform", "platform"}
+	require.Error(t, team.SetSettings(settings))
+}
+
+func TestTeamSettings_ReviewCapacity(t *testing.T) {
+	settings := DefaultTeamSettings()
+
+	_, ok := settings.ReviewCapacity(User{ID: "u1"})
+	require.False(t, ok)
+
+	teamDefault := 3
+	settings.DefaultReviewCapacity = &teamDefault
+
+	capacity, ok := settings.ReviewCapacity(User{ID: "u1"})
+	require.True(t, ok)
+	require.Equal(t, 3, capacity)
+
+	personal := 1
+	capacity, ok = settings.ReviewCapacity(User{ID: "u2", ReviewCapacity: &personal})
+	require.True(t, ok)
+	require.Equal(t, 1, capacity)
+}
+
+func TestTeamSettings_Validate_CapacityPolicy(t *testing.T) {
+	settings := DefaultTeamSettings()
+	require.Equal(t, CapacityPolicyAssignAnyway, settings.CapacityPolicy)
+
+	settings.CapacityPolicy = "SOMETIMES"
+	require.Error(t, settings.Validate())
+
+	settings.CapacityPolicy = CapacityPolicyNoCandidate
+	negative := -1
+	settings.DefaultReviewCapacity = &negative
+	require.Error(t, settings.Validate())
+}
+
+func TestTeamSettings_OffHours(t *testing.T) {
+	wh, err := ParseWorkingHours("09:00", "18:00")
+	require.NoError(t, err)
+	u := User{ID: "u1", WorkingHours: wh}
+
+	// 06:00 UTC — до начала рабочего дня 3 часа
+	now := time.Date(2025, 7, 10, 6, 0, 0, 0, time.UTC)
+
+	settings := DefaultTeamSettings()
+	require.Empty(t, settings.OffHours(u, now), "policy is off")
+
+	settings.PreferWorkingHours = true
+	require.Contains(t, settings.OffHours(u, now), "outside working hours")
+
+	settings.WorkingHoursLookahead = 3
+	require.Empty(t, settings.OffHours(u, now))
+
+	settings.WorkingHoursLookahead = MaxWorkingHoursLookahead + 1
+	require.Error(t, settings.Validate())
+}
+
+func TestTeamSettings_WithDefaults(t *testing.T) {
+	got := TeamSettings{}.WithDefaults()
+	require.Equal(t, DefaultReviewersCount, got.ReviewersCount)
+	require.Equal(t, CapacityPolicyAssignAnyway, got.CapacityPolicy)
+	require.Equal(t, DefaultPairRotationWindowDays, got.PairRotationWindowDays)
+	require.Equal(t, SeniorityFallbackReject, got.SeniorityFallback)
+	require.NoError(t, got.Validate())
+}
+
+func TestTeamSettings_Validate_SeniorityPolicy(t *testing.T) {
+	settings := DefaultTeamSettings()
+	require.Equal(t, SeniorityFallbackReject, settings.SeniorityFallback)
+
+	settings.MinSeniorReviewers = settings.ReviewersCount + 1
+	require.Error(t, settings.Validate())
+
+	settings.MinSeniorReviewers = 1
+	negative := -1
+	settings.MaxJuniorReviewers = &negative
+	require.Error(t, settings.Validate())
+
+	one := 1
+	settings.MaxJuniorReviewers = &one
+	settings.SeniorityFallback = "SOMETIMES"
+	require.Error(t, settings.Validate())
+
+	settings.SeniorityFallback = SeniorityFallbackIgnore
+	require.NoError(t, settings.Validate())
+}
+
+func TestTeamSettings_Validate_MinApprovals(t *testing.T) {
+	settings := DefaultTeamSettings()
+	require.Zero(t, settings.MinApprovals)
+	require.False(t, settings.BlockOnChangesRequested)
+
+	settings.MinApprovals = settings.ReviewersCount + 1
+	require.Error(t, settings.Validate())
+
+	settings.MinApprovals = -1
+	require.Error(t, settings.Validate())
+
+	settings.MinApprovals = settings.ReviewersCount
+	require.NoError(t, settings.Validate())
+}
+
+func TestTeamSettings_ReviewersFor_LargePR(t *testing.T) {
+	settings := DefaultTeamSettings()
+	large := PRMetadata{LinesAdded: 400, LinesDeleted: 200}
+	require.Equal(t, settings.ReviewersCount, settings.ReviewersFor(large, nil))
+
+	settings.LargePRLines = 500
+	require.Equal(t, settings.ReviewersCount, settings.ReviewersFor(PRMetadata{LinesAdded: 500}, nil))
+	require.Equal(t, settings.ReviewersCount+1, settings.ReviewersFor(large, nil))
+
+	settings.LargePRExtraReviewers = MaxReviewersCount
+	require.NoError(t, settings.Validate())
+	require.Equal(t, MaxReviewersCount, settings.ReviewersFor(large, nil))
+
+	settings.LargePRExtraReviewers = -1
+	require.Error(t, settings.Validate())
+}
+
+func TestTeamSettings_LabelRules(t *testing.T) {
+	settings := DefaultTeamSettings()
+	settings.LabelRules = []LabelRule{
+		{Label: "security", RequireTeam: "security"},
+		{Label: "docs", ReviewersCount: 1},
+	}
+	require.NoError(t, settings.Validate())
+
+	require.Equal(t, 2, settings.ReviewersFor(PRMetadata{}, nil))
+	require.Equal(t, 1, settings.ReviewersFor(PRMetadata{}, []string{"docs"}))
+	require.Equal(t, 3, settings.ReviewersFor(PRMetadata{}, []string{"security"}))
+	require.Equal(t, 2, settings.ReviewersFor(PRMetadata{}, []string{"docs", "security"}))
+	require.Equal(t, []LabelTeam{{Label: "security", Team: "security"}}, settings.LabelTeams([]string{"docs", "security"}))
+
+	team := &Team{Name: "backend"}
+	require.NoError(t, team.SetSettings(settings))
+
+	settings.LabelRules = []LabelRule{{Label: " Security ", RequireTeam: "backend"}}
+	require.Error(t, team.SetSettings(settings))
+
+	settings.LabelRules = []LabelRule{{Label: "wip"}}
+	require.Error(t, settings.Validate())
+
+	settings.LabelRules = []LabelRule{{Label: "docs", ReviewersCount: 1}, {Label: "docs", ReviewersCount: 2}}
+	require.Error(t, settings.Validate())
+}
+
+func TestAddBusinessHours(t *testing.T) {
+	wh := WorkingHours{Start: 9 * 60, End: 18 * 60}
+	// 2026-10-16 — пятница.
+	fri := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2026, 10, 16, 17, 0, 0, 0, time.UTC), AddBusinessHours(fri, time.Hour, wh, time.UTC))
+	require.Equal(t, time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC), AddBusinessHours(fri, 4*time.Hour, wh, time.UTC))
+
+	sat := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), AddBusinessHours(sat, time.Hour, wh, time.UTC))
+
+	night := WorkingHours{Start: 22 * 60, End: 6 * 60}
+	tue := time.Date(2026, 10, 20, 1, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC), AddBusinessHours(tue, 2*time.Hour, night, time.UTC))
+	require.Equal(t, time.Date(2026, 10, 20, 23, 0, 0, 0, time.UTC), AddBusinessHours(tue, 6*time.Hour, night, time.UTC))
+}
+
+func TestTeamSettings_ReviewDueAt(t *testing.T) {
+	settings := DefaultTeamSettings()
+	assigned := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+	require.Nil(t, settings.ReviewDueAt(User{ID: "u1"}, assigned))
+
+	settings.ReviewSLAHours = 2
+	require.NoError(t, settings.Validate())
+	due := settings.ReviewDueAt(User{ID: "u1"}, assigned)
+	require.NotNil(t, due)
+	require.Equal(t, time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), *due)
+
+	late := &WorkingHours{Start: 12 * 60, End: 20 * 60}
+	due = settings.ReviewDueAt(User{ID: "u2", WorkingHours: late}, assigned.Add(3*time.Hour))
+	require.Equal(t, time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC), *due)
+
+	settings.ReviewSLAHours = -1
+	require.Error(t, settings.Validate())
+}
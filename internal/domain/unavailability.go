@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxUnavailabilityReasonLen — ограничение длины причины отсутствия.
+const MaxUnavailabilityReasonLen = 256
+
+// Unavailability — период [StartsAt, EndsAt), когда пользователь не назначается ревьювером
+// (отпуск, больничный). На is_active не влияет.
+type Unavailability struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+func NewUnavailability(userID string, startsAt, endsAt time.Time, reason string) (*Unavailability, error) {
+	if userID == "" {
+		return nil, invalidArgument("user id is empty")
+	}
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return nil, invalidArgument("unavailability start and end are required")
+	}
+	if !endsAt.After(startsAt) {
+		return nil, invalidArgument("unavailability must end after it starts")
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > MaxUnavailabilityReasonLen {
+		return nil, invalidArgument("unavailability reason is longer than %d bytes", MaxUnavailabilityReasonLen)
+	}
+
+	return &Unavailability{
+		UserID:   userID,
+		StartsAt: startsAt.UTC(),
+		EndsAt:   endsAt.UTC(),
+		Reason:   reason,
+	}, nil
+}
+
+// Covers сообщает, попадает ли момент t в период отсутствия.
+func (u Unavailability) Covers(t time.Time) bool {
+	return !t.Before(u.StartsAt) && t.Before(u.EndsAt)
+}
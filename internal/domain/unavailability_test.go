@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUnavailability_Validation(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := NewUnavailability("u1", start, start, "vacation")
+	require.Error(t, err)
+
+	_, err = NewUnavailability("", start, start.Add(time.Hour), "vacation")
+	require.Error(t, err)
+
+	u, err := NewUnavailability("u1", start, start.Add(24*time.Hour), "  vacation ")
+	require.NoError(t, err)
+	require.Equal(t, "vacation", u.Reason)
+}
+
+func TestUnavailability_Covers(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	u := Unavailability{UserID: "u1", StartsAt: start, EndsAt: start.Add(24 * time.Hour)}
+
+	require.True(t, u.Covers(start))
+	require.True(t, u.Covers(start.Add(23*time.Hour)))
+	require.False(t, u.Covers(start.Add(24*time.Hour)))
+	require.False(t, u.Covers(start.Add(-time.Second)))
+}
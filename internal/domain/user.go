@@ -1,12 +1,85 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
 
 type User struct {
-	ID			string
-	Name		string
-	TeamName	string
-	IsActive	bool
+	ID       string
+	Name     string
+	TeamName string
+	IsActive bool
+	// ReviewCapacity — максимум одновременно открытых ревью; nil — берётся значение команды.
+	ReviewCapacity *int
+	// Skills — теги компетенций ("go", "postgres", "frontend"), нормализованы NormalizeSkills.
+	Skills []string
+	// TimeZone — IANA-зона ("Europe/Moscow"); пустая строка — UTC.
+	TimeZone string
+	// WorkingHours — рабочее время в локальной зоне; nil — не задано (считается рабочим всегда).
+	WorkingHours *WorkingHours
+	Seniority    Seniority
+	// ReviewWeight — вес в стратегии weighted (0..100): частичная занятость, дежурства.
+	ReviewWeight int
+}
+
+// WorkingHours — ежедневное окно [Start, End) в минутах от полуночи.
+// End < Start означает окно через полночь (например, 22:00–06:00).
+type WorkingHours struct {
+	Start int
+	End   int
+}
+
+const minutesPerDay = 24 * 60
+
+const (
+	// DefaultReviewWeight — вес полностью занятого участника.
+	DefaultReviewWeight = 100
+	MaxReviewWeight     = 100
+)
+
+// ParseWorkingHours разбирает окно вида "09:00"–"18:00".
+func ParseWorkingHours(start, end string) (*WorkingHours, error) {
+	s, err := parseClock(start)
+	if err != nil {
+		return nil, err
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return nil, err
+	}
+	if s == e {
+		return nil, invalidArgument("working hours start and end must differ")
+	}
+	return &WorkingHours{Start: s, End: e}, nil
+}
+
+func parseClock(v string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(v))
+	if err != nil {
+		return 0, invalidArgument("bad time of day %q, expected HH:MM", v)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
+func (w WorkingHours) StartClock() string { return formatClock(w.Start) }
+func (w WorkingHours) EndClock() string   { return formatClock(w.End) }
+
+func (w WorkingHours) String() string {
+	return w.StartClock() + "-" + w.EndClock()
+}
+
+func (w WorkingHours) contains(minute int) bool {
+	if w.Start < w.End {
+		return minute >= w.Start && minute < w.End
+	}
+	return minute >= w.Start || minute < w.End
 }
 
 func NewUser(id, username, teamName string, isActive bool) (*User, error) {
@@ -14,10 +87,11 @@ func NewUser(id, username, teamName string, isActive bool) (*User, error) {
 		return nil, fmt.Errorf("empty parameter")
 	}
 	return &User{
-		ID: id,
-		Name: username,
-		TeamName: teamName,
-		IsActive: isActive,
+		ID:           id,
+		Name:         username,
+		TeamName:     teamName,
+		IsActive:     isActive,
+		ReviewWeight: DefaultReviewWeight,
 	}, nil
 }
 
@@ -27,4 +101,108 @@ func (u *User) Deactivate() {
 
 func (u *User) Activate() {
 	u.IsActive = true
-}
\ No newline at end of file
+}
+
+func (u *User) SetReviewCapacity(capacity *int) error {
+	if capacity != nil && *capacity < 0 {
+		return invalidArgument("review capacity must be >= 0, got %d", *capacity)
+	}
+	u.ReviewCapacity = capacity
+	return nil
+}
+
+// SetSchedule задаёт часовой пояс и рабочее время; wh=nil снимает ограничение.
+func (u *User) SetSchedule(timeZone string, wh *WorkingHours) error {
+	timeZone = strings.TrimSpace(timeZone)
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return invalidArgument("unknown time zone %q", timeZone)
+	}
+	if wh != nil && (wh.Start < 0 || wh.Start >= minutesPerDay || wh.End < 0 || wh.End >= minutesPerDay || wh.Start == wh.End) {
+		return invalidArgument("bad working hours %s", wh)
+	}
+	u.TimeZone = timeZone
+	u.WorkingHours = wh
+	return nil
+}
+
+// Location возвращает часовой пояс пользователя; при пустой или неизвестной зоне — UTC.
+func (u User) Location() *time.Location {
+	if u.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// UntilWorkingHours возвращает, сколько осталось до начала рабочего времени; 0 — если оно уже идёт
+// или рабочее время не задано.
+func (u User) UntilWorkingHours(now time.Time) time.Duration {
+	if u.WorkingHours == nil {
+		return 0
+	}
+	local := now.In(u.Location())
+	minute := local.Hour()*60 + local.Minute()
+	if u.WorkingHours.contains(minute) {
+		return 0
+	}
+
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	start := midnight.Add(time.Duration(u.WorkingHours.Start) * time.Minute)
+	if !start.After(local) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start.Sub(local)
+}
+
+func (u *User) SetSkills(skills []string) {
+	u.Skills = NormalizeSkills(skills)
+}
+
+// SetReviewWeight задаёт вес в лотерее ревьюверов: 0 — только если больше некого назначить.
+func (u *User) SetReviewWeight(weight int) error {
+	if weight < 0 || weight > MaxReviewWeight {
+		return invalidArgument("review weight must be in [0, %d], got %d", MaxReviewWeight, weight)
+	}
+	u.ReviewWeight = weight
+	return nil
+}
+
+func (u *User) SetSeniority(level string) error {
+	seniority, err := ParseSeniority(level)
+	if err != nil {
+		return err
+	}
+	u.Seniority = seniority
+	return nil
+}
+
+func (u *User) HasSkill(skill string) bool {
+	for _, s := range u.Skills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeSkills приводит теги к нижнему регистру, убирает пустые и дубликаты и сортирует.
+func NormalizeSkills(skills []string) []string {
+	seen := make(map[string]struct{}, len(skills))
+	out := make([]string, 0, len(skills))
+	for _, s := range skills {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		if _, exists := seen[s]; exists {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
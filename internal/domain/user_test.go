@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeSkills(t *testing.T) {
+	got := NormalizeSkills([]string{" Go", "postgres", "", "go", "Frontend "})
+	require.Equal(t, []string{"frontend", "go", "postgres"}, got)
+}
+
+func TestUser_SetSkills_HasSkill(t *testing.T) {
+	u := &User{ID: "u1"}
+	u.SetSkills([]string{"GO", "postgres"})
+
+	require.True(t, u.HasSkill("go"))
+	require.True(t, u.HasSkill("postgres"))
+	require.False(t, u.HasSkill("frontend"))
+}
+
+func TestUser_SetReviewCapacity(t *testing.T) {
+	u := &User{ID: "u1"}
+
+	negative := -1
+	require.Error(t, u.SetReviewCapacity(&negative))
+
+	three := 3
+	require.NoError(t, u.SetReviewCapacity(&three))
+	require.Equal(t, 3, *u.ReviewCapacity)
+
+	require.NoError(t, u.SetReviewCapacity(nil))
+	require.Nil(t, u.ReviewCapacity)
+}
+
+func TestUser_UntilWorkingHours(t *testing.T) {
+	wh, err := ParseWorkingHours("09:00", "18:00")
+	require.NoError(t, err)
+
+	u := User{ID: "u1"}
+	require.NoError(t, u.SetSchedule("Asia/Tokyo", wh))
+
+	// 01:00 UTC = 10:00 в Токио
+	at := time.Date(2025, 7, 10, 1, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Duration(0), u.UntilWorkingHours(at))
+
+	// 10:00 UTC = 19:00 в Токио, до 09:00 следующего дня — 14 часов
+	at = time.Date(2025, 7, 10, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, 14*time.Hour, u.UntilWorkingHours(at))
+
+	require.Equal(t, time.Duration(0), User{ID: "u2"}.UntilWorkingHours(at))
+}
+
+func TestUser_WorkingHoursOvernight(t *testing.T) {
+	wh, err := ParseWorkingHours("22:00", "06:00")
+	require.NoError(t, err)
+
+	u := User{ID: "u1", WorkingHours: wh}
+	require.Equal(t, time.Duration(0), u.UntilWorkingHours(time.Date(2025, 7, 10, 23, 30, 0, 0, time.UTC)))
+	require.Equal(t, time.Duration(0), u.UntilWorkingHours(time.Date(2025, 7, 10, 5, 59, 0, 0, time.UTC)))
+	require.Equal(t, 12*time.Hour, u.UntilWorkingHours(time.Date(2025, 7, 10, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestUser_SetSchedule_Validation(t *testing.T) {
+	u := User{ID: "u1"}
+	require.Error(t, u.SetSchedule("Mars/Olympus", nil))
+
+	_, err := ParseWorkingHours("9am", "18:00")
+	require.Error(t, err)
+
+	_, err = ParseWorkingHours("09:00", "09:00")
+	require.Error(t, err)
+}
+
+func TestUser_SetSeniority(t *testing.T) {
+	u := User{ID: "u1"}
+	require.NoError(t, u.SetSeniority(" Senior "))
+	require.Equal(t, SenioritySenior, u.Seniority)
+	require.True(t, u.Seniority.IsSenior())
+	require.True(t, SeniorityLead.IsSenior())
+	require.False(t, SeniorityMiddle.IsSenior())
+
+	require.NoError(t, u.SetSeniority(""))
+	require.Equal(t, Seniority(""), u.Seniority)
+
+	require.Error(t, u.SetSeniority("principal"))
+}
+
+func TestUser_SetReviewWeight(t *testing.T) {
+	u, err := NewUser("u1", "Alice", "backend", true)
+	require.NoError(t, err)
+	require.Equal(t, DefaultReviewWeight, u.ReviewWeight)
+
+	require.NoError(t, u.SetReviewWeight(0))
+	require.Equal(t, 0, u.ReviewWeight)
+	require.NoError(t, u.SetReviewWeight(40))
+	require.Equal(t, 40, u.ReviewWeight)
+
+	require.Error(t, u.SetReviewWeight(-1))
+	require.Error(t, u.SetReviewWeight(MaxReviewWeight+1))
+	require.Equal(t, 40, u.ReviewWeight)
+}
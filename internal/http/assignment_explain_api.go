@@ -0,0 +1,110 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/usecase"
+)
+
+type candidateSnapshotDTO struct {
+	UserID         string     `json:"user_id"`
+	OpenReviews    int        `json:"open_reviews"`
+	LastAssignedAt *time.Time `json:"last_assigned_at,omitempty"`
+	PairScore      float64    `json:"pair_score,omitempty"`
+	Weight         int        `json:"weight"`
+}
+
+type selectionRoundDTO struct {
+	Note       string                 `json:"note,omitempty"`
+	Count      int                    `json:"count"`
+	Assigned   []string               `json:"assigned"`
+	Candidates []candidateSnapshotDTO `json:"candidates"`
+	Picked     []string               `json:"picked"`
+}
+
+type assignmentExplanationDTO struct {
+	ID               int64                `json:"id"`
+	Kind             string               `json:"kind"`
+	Strategy         string               `json:"strategy"`
+	Seed             int64                `json:"seed"`
+	Reviewers        []string             `json:"reviewers"`
+	ReplacedReviewer string               `json:"replaced_reviewer,omitempty"`
+	Rounds           []selectionRoundDTO  `json:"rounds"`
+	Excluded         []skippedReviewerDTO `json:"excluded"`
+	Reason           string               `json:"reason"`
+	// Replayed — результат повторного прогона стратегии по сохранённым данным.
+	Replayed   []string  `json:"replayed"`
+	Reproduced bool      `json:"reproduced"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type assignmentExplainResponse struct {
+	PullRequestID string                     `json:"pull_request_id"`
+	Assignments   []assignmentExplanationDTO `json:"assignments"`
+}
+
+func assignmentExplanationToDTO(e usecase.AssignmentExplain) assignmentExplanationDTO {
+	out := assignmentExplanationDTO{
+		ID:               e.ID,
+		Kind:             string(e.Kind),
+		Strategy:         e.Strategy,
+		Seed:             e.Seed,
+		Reviewers:        append([]string{}, e.Reviewers...),
+		ReplacedReviewer: e.ReplacedReviewer,
+		Rounds:           make([]selectionRoundDTO, 0, len(e.Rounds)),
+		Excluded:         make([]skippedReviewerDTO, 0, len(e.Excluded)),
+		Reason:           e.Reason,
+		Replayed:         append([]string{}, e.Replayed...),
+		Reproduced:       e.Reproduced,
+		CreatedAt:        e.CreatedAt,
+	}
+
+	for _, round := range e.Rounds {
+		rd := selectionRoundDTO{
+			Note:       round.Note,
+			Count:      round.Count,
+			Assigned:   append([]string{}, round.Assigned...),
+			Candidates: make([]candidateSnapshotDTO, 0, len(round.Candidates)),
+			Picked:     append([]string{}, round.Picked...),
+		}
+		for _, c := range round.Candidates {
+			rd.Candidates = append(rd.Candidates, candidateSnapshotDTO(c))
+		}
+		out.Rounds = append(out.Rounds, rd)
+	}
+	for _, ex := range e.Excluded {
+		out.Excluded = append(out.Excluded, skippedReviewerDTO{UserID: ex.UserID, Reason: ex.Reason})
+	}
+
+	return out
+}
+
+// GET /pullRequest/assignmentExplain?pull_request_id=...
+func (s *Server) handleAssignmentExplain(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		http.Error(w, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	explained, err := s.prs.ExplainAssignment(r.Context(), s.db, prID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := assignmentExplainResponse{
+		PullRequestID: prID,
+		Assignments:   make([]assignmentExplanationDTO, 0, len(explained)),
+	}
+	for _, e := range explained {
+		resp.Assignments = append(resp.Assignments, assignmentExplanationToDTO(e))
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,130 @@
+package httpapi
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+// Максимальный размер импортируемого файла CODEOWNERS.
+const maxCodeOwnersSize = 1 << 20
+
+type codeOwnerRuleDTO struct {
+	ID      int64    `json:"id"`
+	Pattern string   `json:"pattern"`
+	Teams   []string `json:"teams"`
+	Users   []string `json:"users"`
+	Source  string   `json:"source"`
+}
+
+type addCodeOwnerRequest struct {
+	Pattern string   `json:"pattern"`
+	Teams   []string `json:"teams"`
+	Users   []string `json:"users"`
+}
+
+type codeOwnerRuleResponse struct {
+	Rule codeOwnerRuleDTO `json:"rule"`
+}
+
+type codeOwnerRulesResponse struct {
+	Rules []codeOwnerRuleDTO `json:"rules"`
+}
+
+type codeOwnersImportResponse struct {
+	Imported int                `json:"imported"`
+	Rules    []codeOwnerRuleDTO `json:"rules"`
+	Warnings []string           `json:"warnings"`
+}
+
+func codeOwnerRuleToDTO(r domain.CodeOwnerRule) codeOwnerRuleDTO {
+	return codeOwnerRuleDTO{
+		ID:      r.ID,
+		Pattern: r.Pattern,
+		Teams:   append([]string{}, r.Teams...),
+		Users:   append([]string{}, r.Users...),
+		Source:  string(r.Source),
+	}
+}
+
+func codeOwnerRulesToDTO(rules []domain.CodeOwnerRule) []codeOwnerRuleDTO {
+	out := make([]codeOwnerRuleDTO, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, codeOwnerRuleToDTO(r))
+	}
+	return out
+}
+
+// POST /codeOwners/add
+func (s *Server) handleCodeOwnersAdd(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req addCodeOwnerRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Pattern == "" {
+		http.Error(w, "pattern is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	rule, err := s.codeOwners.AddRule(ctx, req.Pattern, req.Teams, req.Users)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, codeOwnerRuleResponse{Rule: codeOwnerRuleToDTO(*rule)})
+}
+
+// GET /codeOwners/list
+func (s *Server) handleCodeOwnersList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	rules, err := s.codeOwners.ListRules(ctx, s.db)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, codeOwnerRulesResponse{Rules: codeOwnerRulesToDTO(rules)})
+}
+
+// POST /codeOwners/import  (админский; тело — содержимое файла CODEOWNERS)
+func (s *Server) handleCodeOwnersImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCodeOwnersSize))
+	if err != nil {
+		http.Error(w, "bad request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	res, err := s.codeOwners.ImportCodeOwners(ctx, string(body))
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, codeOwnersImportResponse{
+		Imported: len(res.Rules),
+		Rules:    codeOwnerRulesToDTO(res.Rules),
+		Warnings: append([]string{}, res.Warnings...),
+	})
+}
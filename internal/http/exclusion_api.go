@@ -0,0 +1,119 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+// reviewerExclusionDTO — пара с точки зрения user_id: other_user_id не ревьюит его PR, и наоборот.
+type reviewerExclusionDTO struct {
+	UserID      string    `json:"user_id"`
+	OtherUserID string    `json:"other_user_id"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type reviewerExclusionRequest struct {
+	UserID      string `json:"user_id"`
+	OtherUserID string `json:"other_user_id"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type userExclusionsResponse struct {
+	UserID     string                 `json:"user_id"`
+	Exclusions []reviewerExclusionDTO `json:"exclusions"`
+}
+
+func reviewerExclusionToDTO(userID string, e domain.ReviewerExclusion) reviewerExclusionDTO {
+	return reviewerExclusionDTO{
+		UserID:      userID,
+		OtherUserID: e.Other(userID),
+		Reason:      e.Reason,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+func (s *Server) writeUserExclusions(w http.ResponseWriter, r *http.Request, status int, userID string) {
+	exclusions, err := s.users.ListReviewerExclusions(r.Context(), s.db, userID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := userExclusionsResponse{
+		UserID:     userID,
+		Exclusions: make([]reviewerExclusionDTO, 0, len(exclusions)),
+	}
+	for _, e := range exclusions {
+		resp.Exclusions = append(resp.Exclusions, reviewerExclusionToDTO(userID, e))
+	}
+	s.writeJSON(w, status, resp)
+}
+
+// POST /users/addExclusion
+func (s *Server) handleAddExclusion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req reviewerExclusionRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" || req.OtherUserID == "" {
+		http.Error(w, "user_id and other_user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := s.users.AddReviewerExclusion(ctx, s.db, req.UserID, req.OtherUserID, req.Reason); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeUserExclusions(w, r, http.StatusCreated, req.UserID)
+}
+
+// GET /users/getExclusions?user_id=...
+func (s *Server) handleGetExclusions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	s.writeUserExclusions(w, r, http.StatusOK, userID)
+}
+
+// POST /users/deleteExclusion
+func (s *Server) handleDeleteExclusion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req reviewerExclusionRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" || req.OtherUserID == "" {
+		http.Error(w, "user_id and other_user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.users.DeleteReviewerExclusion(ctx, s.db, req.UserID, req.OtherUserID); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeUserExclusions(w, r, http.StatusOK, req.UserID)
+}
@@ -2,9 +2,11 @@ package httpapi
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
@@ -14,14 +16,16 @@ import (
 )
 
 type Server struct {
-	mux       *http.ServeMux
-	teams     *usecase.TeamService
-	users     *usecase.UserService
-	prs       *usecase.PRService
-	stats     *usecase.StatsService
-	db        repository.DBExecutor 
-	logger    log.Logger
-	baseCtxFn func() context.Context
+	mux         *http.ServeMux
+	teams       *usecase.TeamService
+	users       *usecase.UserService
+	prs         *usecase.PRService
+	stats       *usecase.StatsService
+	codeOwners  *usecase.CodeOwnerService
+	db          repository.DBExecutor
+	logger      log.Logger
+	adminTokens []string
+	baseCtxFn   func() context.Context
 }
 
 func NewServer(
@@ -29,18 +33,22 @@ func NewServer(
 	users *usecase.UserService,
 	prs *usecase.PRService,
 	stats *usecase.StatsService,
+	codeOwners *usecase.CodeOwnerService,
 	db repository.DBExecutor,
 	logger log.Logger,
+	adminTokens []string,
 ) *Server {
 	s := &Server{
-		mux:       http.NewServeMux(),
-		teams:     teams,
-		users:     users,
-		prs:       prs,
-		stats:     stats,
-		db:        db,
-		logger:    logger,
-		baseCtxFn: context.Background,
+		mux:         http.NewServeMux(),
+		teams:       teams,
+		users:       users,
+		prs:         prs,
+		stats:       stats,
+		codeOwners:  codeOwners,
+		db:          db,
+		logger:      logger,
+		adminTokens: adminTokens,
+		baseCtxFn:   context.Background,
 	}
 
 	s.registerRoutes()
@@ -62,14 +70,100 @@ type errorResponse struct {
 }
 
 type teamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID       string           `json:"user_id"`
+	Username     string           `json:"username"`
+	IsActive     bool             `json:"is_active"`
+	Skills       []string         `json:"skills,omitempty"`
+	TimeZone     string           `json:"time_zone,omitempty"`
+	WorkingHours *workingHoursDTO `json:"working_hours,omitempty"`
+	Seniority    string           `json:"seniority,omitempty"`
+}
+
+// workingHoursDTO — рабочее время в локальной зоне пользователя, "HH:MM".
+type workingHoursDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type teamSettingsDTO struct {
+	ReviewersCount        int      `json:"reviewers_count,omitempty"`
+	FallbackTeams         []string `json:"fallback_teams"`
+	DefaultReviewCapacity *int     `json:"default_review_capacity"`
+	CapacityPolicy        string   `json:"capacity_policy,omitempty"`
+	PreferWorkingHours    bool     `json:"prefer_working_hours"`
+	WorkingHoursLookahead int      `json:"working_hours_lookahead"`
+	// PairRotationWindowDays — окно истории пар для стратегии pair_rotation (0 — по умолчанию).
+	PairRotationWindowDays int    `json:"pair_rotation_window_days,omitempty"`
+	MinSeniorReviewers     int    `json:"min_senior_reviewers"`
+	MaxJuniorReviewers     *int   `json:"max_junior_reviewers"`
+	SeniorityFallback      string `json:"seniority_fallback,omitempty"`
+	// MinApprovals и BlockOnChangesRequested — политика merge PR авторов команды.
+	MinApprovals            int  `json:"min_approvals"`
+	BlockOnChangesRequested bool `json:"block_on_changes_requested"`
+	// LargePRLines — сколько изменённых строк делает PR большим (0 — правило выключено);
+	// большому PR нужно ещё LargePRExtraReviewers ревьюверов.
+	LargePRLines          int `json:"large_pr_lines"`
+	LargePRExtraReviewers int `json:"large_pr_extra_reviewers,omitempty"`
+	// LabelRules — правила меток PR: reviewers_count заменяет число ревьюверов,
+	// require_team добавляет ревьювера из указанной команды.
+	LabelRules []labelRuleDTO `json:"label_rules"`
+	// ReviewSLAHours — срок ревью в рабочих часах ревьювера (0 — без SLA).
+	ReviewSLAHours int `json:"review_sla_hours"`
+	// StaleReviewHours — через сколько часов без вердикта ревью переназначается автоматически (0 — никогда).
+	StaleReviewHours int `json:"stale_review_hours"`
+}
+
+type labelRuleDTO struct {
+	Label          string `json:"label"`
+	ReviewersCount int    `json:"reviewers_count,omitempty"`
+	RequireTeam    string `json:"require_team,omitempty"`
 }
 
 type teamDTO struct {
-	TeamName string          `json:"team_name"`
-	Members  []teamMemberDTO `json:"members"`
+	TeamName string           `json:"team_name"`
+	Members  []teamMemberDTO  `json:"members"`
+	Settings *teamSettingsDTO `json:"settings,omitempty"`
+}
+
+type setTeamSettingsRequest struct {
+	TeamName                string          `json:"team_name"`
+	ReviewersCount          *int            `json:"reviewers_count,omitempty"`
+	FallbackTeams           *[]string       `json:"fallback_teams,omitempty"`
+	DefaultReviewCapacity   nullable[int]   `json:"default_review_capacity"`
+	CapacityPolicy          *string         `json:"capacity_policy,omitempty"`
+	PreferWorkingHours      *bool           `json:"prefer_working_hours,omitempty"`
+	WorkingHoursLookahead   *int            `json:"working_hours_lookahead,omitempty"`
+	PairRotationWindowDays  *int            `json:"pair_rotation_window_days,omitempty"`
+	MinSeniorReviewers      *int            `json:"min_senior_reviewers,omitempty"`
+	MaxJuniorReviewers      nullable[int]   `json:"max_junior_reviewers"`
+	SeniorityFallback       *string         `json:"seniority_fallback,omitempty"`
+	MinApprovals            *int            `json:"min_approvals,omitempty"`
+	BlockOnChangesRequested *bool           `json:"block_on_changes_requested,omitempty"`
+	LargePRLines            *int            `json:"large_pr_lines,omitempty"`
+	LargePRExtraReviewers   *int            `json:"large_pr_extra_reviewers,omitempty"`
+	LabelRules              *[]labelRuleDTO `json:"label_rules,omitempty"`
+	ReviewSLAHours          *int            `json:"review_sla_hours,omitempty"`
+	StaleReviewHours        *int            `json:"stale_review_hours,omitempty"`
+}
+
+// nullable отличает отсутствующее поле (Set=false) от явного null (Set=true, Value=nil).
+type nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (n *nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Value = &v
+	return nil
 }
 
 type teamAddResponse struct {
@@ -82,10 +176,50 @@ type setIsActiveRequest struct {
 }
 
 type userDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string           `json:"user_id"`
+	Username       string           `json:"username"`
+	TeamName       string           `json:"team_name"`
+	IsActive       bool             `json:"is_active"`
+	ReviewCapacity *int             `json:"review_capacity,omitempty"`
+	Skills         []string         `json:"skills"`
+	TimeZone       string           `json:"time_zone,omitempty"`
+	WorkingHours   *workingHoursDTO `json:"working_hours,omitempty"`
+	Seniority      string           `json:"seniority,omitempty"`
+	ReviewWeight   int              `json:"review_weight"`
+}
+
+type setScheduleRequest struct {
+	UserID       string           `json:"user_id"`
+	TimeZone     string           `json:"time_zone"`
+	WorkingHours *workingHoursDTO `json:"working_hours"`
+}
+
+type setSeniorityRequest struct {
+	UserID    string `json:"user_id"`
+	Seniority string `json:"seniority"`
+}
+
+type setReviewWeightRequest struct {
+	UserID string `json:"user_id"`
+	Weight *int   `json:"weight"`
+}
+
+type setSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+type setCapacityRequest struct {
+	UserID         string `json:"user_id"`
+	ReviewCapacity *int   `json:"review_capacity"`
+}
+
+type userLoadResponse struct {
+	UserID         string `json:"user_id"`
+	OpenReviews    int    `json:"open_reviews"`
+	ReviewCapacity *int   `json:"review_capacity"`
+	CapacitySource string `json:"capacity_source,omitempty"`
+	AtCapacity     bool   `json:"at_capacity"`
 }
 
 type setIsActiveResponse struct {
@@ -93,32 +227,131 @@ type setIsActiveResponse struct {
 }
 
 type createPRRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	RequiredSkills  []string `json:"required_skills,omitempty"`
+	ChangedFiles    []string `json:"changed_files,omitempty"`
+	// Reviewers — явно запрошенные автором ревьюверы; остальные слоты заполняются автоматически.
+	Reviewers []string `json:"reviewers,omitempty"`
+	// ExcludeReviewers — кого не назначать на этот PR (действует и при переназначении).
+	ExcludeReviewers []string `json:"exclude_reviewers,omitempty"`
+	// Draft — создать PR в статусе DRAFT; ревьюверы назначаются при /pullRequest/markReady.
+	Draft  bool     `json:"draft,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	// Priority — low, normal (по умолчанию), high или hotfix.
+	Priority string `json:"priority,omitempty"`
+	// Seed — seed из ответа /pullRequest/suggestReviewers: при тех же данных назначаются те же ревьюверы.
+	Seed *int64 `json:"seed,omitempty"`
+	prMetadataDTO
+}
+
+// prMetadataDTO — необязательные сведения о PR из VCS; поля встраиваются в запрос и ответ.
+type prMetadataDTO struct {
+	Repository   string `json:"repository,omitempty"`
+	SourceBranch string `json:"source_branch,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+	URL          string `json:"url,omitempty"`
+	Description  string `json:"description,omitempty"`
+	LinesAdded   int    `json:"lines_added,omitempty"`
+	LinesDeleted int    `json:"lines_deleted,omitempty"`
+	FilesChanged int    `json:"files_changed,omitempty"`
 }
 
 type pullRequestDTO struct {
-	ID                string     `json:"pull_request_id"`
-	Name              string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	ID                string   `json:"pull_request_id"`
+	Name              string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	ReviewersRequired int      `json:"reviewers_required,omitempty"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	// FallbackReviewers — ревьюверы, назначенные из fallback-команд.
+	FallbackReviewers []fallbackReviewerDTO `json:"fallback_reviewers,omitempty"`
+	RequiredSkills    []string              `json:"required_skills,omitempty"`
+	// UncoveredSkills — требуемые навыки, которые не покрыл ни один ревьювер.
+	UncoveredSkills []string `json:"uncovered_skills,omitempty"`
+	ChangedFiles    []string `json:"changed_files,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Priority        string   `json:"priority,omitempty"`
+	// ExcludedReviewers — кого автор попросил не назначать на этот PR.
+	ExcludedReviewers []string `json:"excluded_reviewers,omitempty"`
+	// Reviews — состояние ревью по каждому назначенному ревьюверу (PENDING — вердикта ещё нет).
+	Reviews []reviewStateDTO `json:"reviews,omitempty"`
+	// ReviewDueAt — ближайший срок ревью среди ревьюверов без вердикта (по SLA команды автора).
+	ReviewDueAt *time.Time `json:"reviewDueAt,omitempty"`
+	// AssignmentReason и SkippedReviewers — объяснение последнего выбора ревьюверов.
+	AssignmentReason string               `json:"assignment_reason,omitempty"`
+	SkippedReviewers []skippedReviewerDTO `json:"skipped_reviewers,omitempty"`
+	CreatedAt        *time.Time           `json:"createdAt,omitempty"`
+	MergedAt         *time.Time           `json:"mergedAt,omitempty"`
+	ClosedAt         *time.Time           `json:"closedAt,omitempty"`
+	prMetadataDTO
+}
+
+type skippedReviewerDTO struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+// reviewStatePending — вердикт ревьювера ещё не получен.
+const reviewStatePending = "PENDING"
+
+type reviewStateDTO struct {
+	UserID      string     `json:"user_id"`
+	State       string     `json:"state"`
+	Message     string     `json:"message,omitempty"`
+	SubmittedAt *time.Time `json:"submittedAt,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+}
+
+type fallbackReviewerDTO struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
 }
 
 type pullRequestResponse struct {
 	PR pullRequestDTO `json:"pr"`
 }
 
+type suggestedCandidateDTO struct {
+	UserID      string `json:"user_id"`
+	TeamName    string `json:"team_name"`
+	Rank        int    `json:"rank"`
+	OpenReviews int    `json:"open_reviews"`
+	Selected    bool   `json:"selected"`
+	Note        string `json:"note,omitempty"`
+}
+
+type suggestReviewersResponse struct {
+	PR         pullRequestDTO          `json:"pr"`
+	Candidates []suggestedCandidateDTO `json:"candidates"`
+	Seed       int64                   `json:"seed"`
+}
+
 type mergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	// Force — merge в обход политики команды; только с админским токеном.
+	Force bool `json:"force,omitempty"`
 }
 
 type reassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_user_id"`
+	// NewUserID — необязательная конкретная замена; без него замена выбирается стратегией.
+	NewUserID string `json:"new_user_id,omitempty"`
+}
+
+// reviewerRequest — тело addReviewer и removeReviewer.
+type reviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+type submitReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	Verdict       string `json:"verdict"`
+	Message       string `json:"message"`
 }
 
 type reassignResponse struct {
@@ -127,10 +360,14 @@ type reassignResponse struct {
 }
 
 type pullRequestShortDTO struct {
-	ID       string `json:"pull_request_id"`
-	Name     string `json:"pull_request_name"`
-	AuthorID string `json:"author_id"`
-	Status   string `json:"status"`
+	ID        string     `json:"pull_request_id"`
+	Name      string     `json:"pull_request_name"`
+	AuthorID  string     `json:"author_id"`
+	Status    string     `json:"status"`
+	Labels    []string   `json:"labels,omitempty"`
+	Priority  string     `json:"priority,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	prMetadataDTO
 }
 
 type userReviewsResponse struct {
@@ -139,32 +376,63 @@ type userReviewsResponse struct {
 }
 
 type assignmentsStatItem struct {
-	UserID string `json:"user_id"`
-	Count  int    `json:"count"`
+	UserID        string  `json:"user_id"`
+	TeamName      string  `json:"team_name"`
+	Count         int     `json:"count"`
+	ReviewWeight  int     `json:"review_weight"`
+	Share         float64 `json:"share"`
+	ExpectedShare float64 `json:"expected_share"`
 }
 
 type assignmentsStatResponse struct {
 	Assignments []assignmentsStatItem `json:"assignments"`
 }
 
-
 func (s *Server) registerRoutes() {
 	s.mux.HandleFunc("POST /team/add", s.handleTeamAdd)
 	s.mux.HandleFunc("GET /team/get", s.handleTeamGet)
+	s.mux.HandleFunc("POST /team/setSettings", s.handleTeamSetSettings)
 
 	s.mux.HandleFunc("POST /users/setIsActive", s.handleSetIsActive)
 	s.mux.HandleFunc("GET /users/getReview", s.handleGetUserReview)
+	s.mux.HandleFunc("POST /users/setCapacity", s.handleSetCapacity)
+	s.mux.HandleFunc("GET /users/getLoad", s.handleGetUserLoad)
+	s.mux.HandleFunc("POST /users/setSkills", s.handleSetSkills)
+	s.mux.HandleFunc("POST /users/setSchedule", s.handleSetSchedule)
+	s.mux.HandleFunc("POST /users/setSeniority", s.handleSetSeniority)
+	s.mux.HandleFunc("POST /users/setWeight", s.handleSetReviewWeight)
+	s.mux.HandleFunc("POST /users/addUnavailability", s.handleAddUnavailability)
+	s.mux.HandleFunc("GET /users/getUnavailability", s.handleGetUnavailability)
+	s.mux.HandleFunc("POST /users/deleteUnavailability", s.handleDeleteUnavailability)
+	s.mux.HandleFunc("POST /users/addExclusion", s.handleAddExclusion)
+	s.mux.HandleFunc("GET /users/getExclusions", s.handleGetExclusions)
+	s.mux.HandleFunc("POST /users/deleteExclusion", s.handleDeleteExclusion)
 
 	s.mux.HandleFunc("POST /pullRequest/create", s.handleCreatePR)
+	s.mux.HandleFunc("POST /pullRequest/suggestReviewers", s.handleSuggestReviewers)
 	s.mux.HandleFunc("POST /pullRequest/merge", s.handleMergePR)
+	s.mux.HandleFunc("POST /pullRequest/addReviewer", s.handleAddReviewer)
+	s.mux.HandleFunc("POST /pullRequest/removeReviewer", s.handleRemoveReviewer)
 	s.mux.HandleFunc("POST /pullRequest/reassign", s.handleReassign)
+	s.mux.HandleFunc("POST /pullRequest/review", s.handleSubmitReview)
+	s.mux.HandleFunc("POST /pullRequest/markReady", s.handleMarkReady)
+	s.mux.HandleFunc("POST /pullRequest/close", s.handleClosePR)
+	s.mux.HandleFunc("POST /pullRequest/reopen", s.handleReopenPR)
+	s.mux.HandleFunc("POST /pullRequest/labels", s.handleSetLabels)
+	s.mux.HandleFunc("POST /pullRequest/priority", s.handleSetPriority)
+	s.mux.HandleFunc("GET /pullRequest/assignmentExplain", s.handleAssignmentExplain)
+
+	s.mux.HandleFunc("GET /reviews/overdue", s.handleOverdueReviews)
 
 	s.mux.HandleFunc("GET /stats/assignments", s.handleStatsAssignments)
 
+	s.mux.HandleFunc("POST /codeOwners/add", s.handleCodeOwnersAdd)
+	s.mux.HandleFunc("GET /codeOwners/list", s.handleCodeOwnersList)
+	s.mux.HandleFunc("POST /codeOwners/import", s.handleCodeOwnersImport)
+
 	s.mux.HandleFunc("GET /health", s.handleHealth)
 }
 
-
 func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -198,8 +466,16 @@ func (s *Server) writeDomainError(w http.ResponseWriter, err error) {
 			status = http.StatusConflict // 409
 		case domain.ErrorCodeNoCandidate:
 			status = http.StatusConflict // 409
+		case domain.ErrorCodeSeniorityUnsatisfied:
+			status = http.StatusConflict // 409
+		case domain.ErrorCodeMergeBlocked:
+			status = http.StatusConflict // 409
+		case domain.ErrorCodeInvalidPRStatus:
+			status = http.StatusConflict // 409
 		case domain.ErrorCodeNotFound:
 			status = http.StatusNotFound // 404
+		case domain.ErrorCodeInvalidArgument:
+			status = http.StatusBadRequest // 400
 		default:
 			status = http.StatusBadRequest
 		}
@@ -217,6 +493,34 @@ func (s *Server) writeDomainError(w http.ResponseWriter, err error) {
 	s.writeJSON(w, http.StatusInternalServerError, body)
 }
 
+func (s *Server) writeError(w http.ResponseWriter, status int, code, msg string) {
+	body := errorResponse{}
+	body.Error.Code = code
+	body.Error.Message = msg
+	s.writeJSON(w, status, body)
+}
+
+// requireAdmin проверяет заголовок "Authorization: Bearer <token>" по ADMIN_TOKENS.
+// Если токены не настроены, админские эндпоинты выключены.
+func (s *Server) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if len(s.adminTokens) == 0 {
+		s.writeError(w, http.StatusForbidden, "FORBIDDEN", "admin endpoints are disabled: ADMIN_TOKENS is not set")
+		return false
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if ok {
+		for _, t := range s.adminTokens {
+			if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(t)) == 1 {
+				return true
+			}
+		}
+	}
+
+	s.writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "admin token required")
+	return false
+}
+
 // Простая утилита для чтения JSON
 func (s *Server) decodeJSON(w http.ResponseWriter, r *http.Request, dest any) bool {
 	dec := json.NewDecoder(r.Body)
@@ -235,65 +539,217 @@ func teamToDTO(t *domain.Team) teamDTO {
 	members := make([]teamMemberDTO, 0, len(t.Members))
 	for _, m := range t.Members {
 		members = append(members, teamMemberDTO{
-			UserID:   m.ID,
-			Username: m.Name,
-			IsActive: m.IsActive,
+			UserID:       m.ID,
+			Username:     m.Name,
+			IsActive:     m.IsActive,
+			Skills:       append([]string(nil), m.Skills...),
+			TimeZone:     m.TimeZone,
+			WorkingHours: workingHoursToDTO(m.WorkingHours),
+			Seniority:    string(m.Seniority),
 		})
 	}
 	return teamDTO{
 		TeamName: t.Name,
 		Members:  members,
+		Settings: &teamSettingsDTO{
+			ReviewersCount:          t.Settings.ReviewersCount,
+			FallbackTeams:           append([]string{}, t.Settings.FallbackTeams...),
+			DefaultReviewCapacity:   t.Settings.DefaultReviewCapacity,
+			CapacityPolicy:          string(t.Settings.CapacityPolicy),
+			PreferWorkingHours:      t.Settings.PreferWorkingHours,
+			WorkingHoursLookahead:   t.Settings.WorkingHoursLookahead,
+			PairRotationWindowDays:  t.Settings.PairRotationWindowDays,
+			MinSeniorReviewers:      t.Settings.MinSeniorReviewers,
+			MaxJuniorReviewers:      t.Settings.MaxJuniorReviewers,
+			SeniorityFallback:       string(t.Settings.SeniorityFallback),
+			MinApprovals:            t.Settings.MinApprovals,
+			BlockOnChangesRequested: t.Settings.BlockOnChangesRequested,
+			LargePRLines:            t.Settings.LargePRLines,
+			LargePRExtraReviewers:   t.Settings.LargePRExtraReviewers,
+			LabelRules:              labelRulesToDTO(t.Settings.LabelRules),
+			ReviewSLAHours:          t.Settings.ReviewSLAHours,
+			StaleReviewHours:        t.Settings.StaleReviewHours,
+		},
 	}
 }
 
+// Незаданные поля берутся из domain.DefaultTeamSettings.
+func teamSettingsFromDTO(dto *teamSettingsDTO) domain.TeamSettings {
+	settings := domain.DefaultTeamSettings()
+	if dto == nil {
+		return settings
+	}
+	if dto.ReviewersCount != 0 {
+		settings.ReviewersCount = dto.ReviewersCount
+	}
+	settings.FallbackTeams = dto.FallbackTeams
+	settings.DefaultReviewCapacity = dto.DefaultReviewCapacity
+	if dto.CapacityPolicy != "" {
+		settings.CapacityPolicy = domain.CapacityPolicy(dto.CapacityPolicy)
+	}
+	settings.PreferWorkingHours = dto.PreferWorkingHours
+	settings.WorkingHoursLookahead = dto.WorkingHoursLookahead
+	if dto.PairRotationWindowDays != 0 {
+		settings.PairRotationWindowDays = dto.PairRotationWindowDays
+	}
+	settings.MinSeniorReviewers = dto.MinSeniorReviewers
+	settings.MaxJuniorReviewers = dto.MaxJuniorReviewers
+	if dto.SeniorityFallback != "" {
+		settings.SeniorityFallback = domain.SeniorityFallback(dto.SeniorityFallback)
+	}
+	settings.MinApprovals = dto.MinApprovals
+	settings.BlockOnChangesRequested = dto.BlockOnChangesRequested
+	settings.LargePRLines = dto.LargePRLines
+	if dto.LargePRExtraReviewers != 0 {
+		settings.LargePRExtraReviewers = dto.LargePRExtraReviewers
+	}
+	settings.LabelRules = labelRulesFromDTO(dto.LabelRules)
+	settings.ReviewSLAHours = dto.ReviewSLAHours
+	settings.StaleReviewHours = dto.StaleReviewHours
+	return settings
+}
+
+func labelRulesToDTO(rules []domain.LabelRule) []labelRuleDTO {
+	out := make([]labelRuleDTO, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, labelRuleDTO(r))
+	}
+	return out
+}
+
+func labelRulesFromDTO(rules []labelRuleDTO) []domain.LabelRule {
+	var out []domain.LabelRule
+	for _, r := range rules {
+		out = append(out, domain.LabelRule(r))
+	}
+	return out
+}
+
+func workingHoursToDTO(wh *domain.WorkingHours) *workingHoursDTO {
+	if wh == nil {
+		return nil
+	}
+	return &workingHoursDTO{Start: wh.StartClock(), End: wh.EndClock()}
+}
+
+func workingHoursFromDTO(dto *workingHoursDTO) (*domain.WorkingHours, error) {
+	if dto == nil {
+		return nil, nil
+	}
+	return domain.ParseWorkingHours(dto.Start, dto.End)
+}
+
 func userToDTO(u *domain.User) userDTO {
 	return userDTO{
-		UserID:   u.ID,
-		Username: u.Name,
-		TeamName: u.TeamName,
-		IsActive: u.IsActive,
+		UserID:         u.ID,
+		Username:       u.Name,
+		TeamName:       u.TeamName,
+		IsActive:       u.IsActive,
+		ReviewCapacity: u.ReviewCapacity,
+		Skills:         append([]string{}, u.Skills...),
+		TimeZone:       u.TimeZone,
+		WorkingHours:   workingHoursToDTO(u.WorkingHours),
+		Seniority:      string(u.Seniority),
+		ReviewWeight:   u.ReviewWeight,
 	}
 }
 
 func prToDTO(p *domain.PullRequest) pullRequestDTO {
-    if p == nil {
-        return pullRequestDTO{}
-    }
-
-    var created *time.Time
-    if !p.CreatedAt.IsZero() {
-        t := p.CreatedAt
-        created = &t
-    }
-
-    var merged *time.Time
-    if p.MergedAt != nil {
-        t := p.MergedAt
-        merged = t
-    }
-
-    return pullRequestDTO{
-        ID:                p.ID,
-        Name:              p.Name,
-        AuthorID:          p.AuthorID,
-        Status:            string(p.Status),
-        AssignedReviewers: append([]string(nil), p.AssignedReviewers...),
-        CreatedAt:         created,
-        MergedAt:          merged,
-    }
+	if p == nil {
+		return pullRequestDTO{}
+	}
+
+	var created *time.Time
+	if !p.CreatedAt.IsZero() {
+		t := p.CreatedAt
+		created = &t
+	}
+
+	var merged *time.Time
+	if p.MergedAt != nil {
+		t := p.MergedAt
+		merged = t
+	}
+
+	var fallback []fallbackReviewerDTO
+	for _, id := range p.AssignedReviewers {
+		if teamName, ok := p.FallbackReviewers[id]; ok {
+			fallback = append(fallback, fallbackReviewerDTO{UserID: id, TeamName: teamName})
+		}
+	}
+
+	var reviews []reviewStateDTO
+	for _, id := range p.AssignedReviewers {
+		state := reviewStateDTO{UserID: id, State: reviewStatePending}
+		if rv, ok := p.Reviews[id]; ok {
+			t := rv.SubmittedAt
+			state.State = string(rv.Verdict)
+			state.Message = rv.Message
+			state.SubmittedAt = &t
+		}
+		if due, ok := p.ReviewDueAt[id]; ok {
+			state.DueAt = &due
+		}
+		reviews = append(reviews, state)
+	}
+
+	var skipped []skippedReviewerDTO
+	for _, sk := range p.SkippedReviewers {
+		skipped = append(skipped, skippedReviewerDTO{UserID: sk.UserID, Reason: sk.Reason})
+	}
+
+	return pullRequestDTO{
+		ID:                p.ID,
+		Name:              p.Name,
+		AuthorID:          p.AuthorID,
+		Status:            string(p.Status),
+		ReviewersRequired: p.ReviewersRequired,
+		AssignedReviewers: append([]string(nil), p.AssignedReviewers...),
+		FallbackReviewers: fallback,
+		RequiredSkills:    append([]string(nil), p.RequiredSkills...),
+		UncoveredSkills:   append([]string(nil), p.UncoveredSkills...),
+		ChangedFiles:      append([]string(nil), p.ChangedFiles...),
+		Labels:            append([]string(nil), p.Labels...),
+		Priority:          string(p.Priority),
+		ExcludedReviewers: append([]string(nil), p.ExcludedReviewers...),
+		Reviews:           reviews,
+		ReviewDueAt:       p.NextReviewDueAt(),
+		AssignmentReason:  p.AssignmentReason,
+		SkippedReviewers:  skipped,
+		CreatedAt:         created,
+		MergedAt:          merged,
+		ClosedAt:          p.ClosedAt,
+		prMetadataDTO:     prMetadataToDTO(p.Metadata),
+	}
 }
 
+func prMetadataToDTO(m domain.PRMetadata) prMetadataDTO {
+	return prMetadataDTO(m)
+}
+
+func prMetadataFromDTO(dto prMetadataDTO) domain.PRMetadata {
+	return domain.PRMetadata(dto)
+}
 
 func prShortToDTO(p domain.PullRequest) pullRequestShortDTO {
+	var created *time.Time
+	if !p.CreatedAt.IsZero() {
+		t := p.CreatedAt
+		created = &t
+	}
+
 	return pullRequestShortDTO{
-		ID:       p.ID,
-		Name:     p.Name,
-		AuthorID: p.AuthorID,
-		Status:   string(p.Status),
+		ID:            p.ID,
+		Name:          p.Name,
+		AuthorID:      p.AuthorID,
+		Status:        string(p.Status),
+		Labels:        append([]string(nil), p.Labels...),
+		Priority:      string(p.Priority),
+		CreatedAt:     created,
+		prMetadataDTO: prMetadataToDTO(p.Metadata),
 	}
 }
 
-
 // POST /team/add
 func (s *Server) handleTeamAdd(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -313,11 +769,25 @@ func (s *Server) handleTeamAdd(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "bad user in request: "+err.Error(), http.StatusBadRequest)
 			return
 		}
+		u.SetSkills(m.Skills)
+		wh, err := workingHoursFromDTO(m.WorkingHours)
+		if err == nil {
+			err = u.SetSchedule(m.TimeZone, wh)
+		}
+		if err == nil {
+			err = u.SetSeniority(m.Seniority)
+		}
+		if err != nil {
+			http.Error(w, "bad user in request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
 		members = append(members, *u)
 	}
 
+	settings := teamSettingsFromDTO(req.Settings)
+
 	ctx := r.Context()
-	team, err := s.teams.AddTeam(ctx, req.TeamName, members)
+	team, err := s.teams.AddTeam(ctx, req.TeamName, members, settings)
 	if err != nil {
 		s.writeDomainError(w, err)
 		return
@@ -351,6 +821,85 @@ func (s *Server) handleTeamGet(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, resp)
 }
 
+// POST /team/setSettings
+func (s *Server) handleTeamSetSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setTeamSettingsRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.TeamName == "" {
+		http.Error(w, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := s.teams.UpdateSettings(ctx, req.TeamName, func(settings *domain.TeamSettings) {
+		if req.ReviewersCount != nil {
+			settings.ReviewersCount = *req.ReviewersCount
+		}
+		if req.FallbackTeams != nil {
+			settings.FallbackTeams = *req.FallbackTeams
+		}
+		if req.DefaultReviewCapacity.Set {
+			settings.DefaultReviewCapacity = req.DefaultReviewCapacity.Value
+		}
+		if req.CapacityPolicy != nil {
+			settings.CapacityPolicy = domain.CapacityPolicy(*req.CapacityPolicy)
+		}
+		if req.PreferWorkingHours != nil {
+			settings.PreferWorkingHours = *req.PreferWorkingHours
+		}
+		if req.WorkingHoursLookahead != nil {
+			settings.WorkingHoursLookahead = *req.WorkingHoursLookahead
+		}
+		if req.PairRotationWindowDays != nil {
+			settings.PairRotationWindowDays = *req.PairRotationWindowDays
+		}
+		if req.MinSeniorReviewers != nil {
+			settings.MinSeniorReviewers = *req.MinSeniorReviewers
+		}
+		if req.MaxJuniorReviewers.Set {
+			settings.MaxJuniorReviewers = req.MaxJuniorReviewers.Value
+		}
+		if req.SeniorityFallback != nil {
+			settings.SeniorityFallback = domain.SeniorityFallback(*req.SeniorityFallback)
+		}
+		if req.MinApprovals != nil {
+			settings.MinApprovals = *req.MinApprovals
+		}
+		if req.BlockOnChangesRequested != nil {
+			settings.BlockOnChangesRequested = *req.BlockOnChangesRequested
+		}
+		if req.LargePRLines != nil {
+			settings.LargePRLines = *req.LargePRLines
+		}
+		if req.LargePRExtraReviewers != nil {
+			settings.LargePRExtraReviewers = *req.LargePRExtraReviewers
+		}
+		if req.LabelRules != nil {
+			settings.LabelRules = labelRulesFromDTO(*req.LabelRules)
+		}
+		if req.ReviewSLAHours != nil {
+			settings.ReviewSLAHours = *req.ReviewSLAHours
+		}
+		if req.StaleReviewHours != nil {
+			settings.StaleReviewHours = *req.StaleReviewHours
+		}
+	})
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := teamAddResponse{Team: teamToDTO(team)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
 // POST /users/setIsActive
 func (s *Server) handleSetIsActive(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -392,7 +941,7 @@ func (s *Server) handleGetUserReview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	uid, prs, err := s.users.GetUserReviews(ctx, s.db, userID)
+	uid, prs, err := s.users.GetUserReviews(ctx, s.db, userID, r.URL.Query().Get("label"))
 	if err != nil {
 		s.writeDomainError(w, err)
 		return
@@ -410,6 +959,177 @@ func (s *Server) handleGetUserReview(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, resp)
 }
 
+// POST /users/setCapacity
+func (s *Server) handleSetCapacity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setCapacityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.users.SetReviewCapacity(ctx, s.db, req.UserID, req.ReviewCapacity)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := setIsActiveResponse{User: userToDTO(user)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /users/setSkills
+func (s *Server) handleSetSkills(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setSkillsRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.users.SetSkills(ctx, s.db, req.UserID, req.Skills)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := setIsActiveResponse{User: userToDTO(user)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /users/setSchedule
+func (s *Server) handleSetSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setScheduleRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	wh, err := workingHoursFromDTO(req.WorkingHours)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.users.SetSchedule(ctx, s.db, req.UserID, req.TimeZone, wh)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := setIsActiveResponse{User: userToDTO(user)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /users/setSeniority
+func (s *Server) handleSetSeniority(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setSeniorityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.users.SetSeniority(ctx, s.db, req.UserID, req.Seniority)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := setIsActiveResponse{User: userToDTO(user)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /users/setWeight
+func (s *Server) handleSetReviewWeight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setReviewWeightRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" || req.Weight == nil {
+		http.Error(w, "user_id and weight are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := s.users.SetReviewWeight(ctx, s.db, req.UserID, *req.Weight)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := setIsActiveResponse{User: userToDTO(user)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// GET /users/getLoad?user_id=...
+func (s *Server) handleGetUserLoad(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	load, err := s.users.GetUserLoad(ctx, s.db, userID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := userLoadResponse{
+		UserID:         load.User.ID,
+		OpenReviews:    load.OpenReviews,
+		ReviewCapacity: load.Capacity,
+		CapacitySource: load.CapacitySource,
+		AtCapacity:     load.AtCapacity(),
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
 // POST /pullRequest/create
 func (s *Server) handleCreatePR(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -427,7 +1147,20 @@ func (s *Server) handleCreatePR(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	pr, err := s.prs.CreatePRWithAutoAssign(ctx, req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := s.prs.CreatePRWithAutoAssign(ctx, usecase.CreatePRInput{
+		ID:               req.PullRequestID,
+		Name:             req.PullRequestName,
+		AuthorID:         req.AuthorID,
+		RequiredSkills:   req.RequiredSkills,
+		ChangedFiles:     req.ChangedFiles,
+		Reviewers:        req.Reviewers,
+		ExcludeReviewers: req.ExcludeReviewers,
+		Draft:            req.Draft,
+		Labels:           req.Labels,
+		Priority:         req.Priority,
+		Seed:             req.Seed,
+		Metadata:         prMetadataFromDTO(req.prMetadataDTO),
+	})
 	if err != nil {
 		s.writeDomainError(w, err)
 		return
@@ -437,6 +1170,52 @@ func (s *Server) handleCreatePR(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusCreated, resp)
 }
 
+// POST /pullRequest/suggestReviewers
+func (s *Server) handleSuggestReviewers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req createPRRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" || req.PullRequestName == "" || req.AuthorID == "" {
+		http.Error(w, "pull_request_id, pull_request_name and author_id are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	suggestion, err := s.prs.SuggestReviewers(ctx, usecase.CreatePRInput{
+		ID:               req.PullRequestID,
+		Name:             req.PullRequestName,
+		AuthorID:         req.AuthorID,
+		RequiredSkills:   req.RequiredSkills,
+		ChangedFiles:     req.ChangedFiles,
+		Reviewers:        req.Reviewers,
+		ExcludeReviewers: req.ExcludeReviewers,
+		Labels:           req.Labels,
+		Priority:         req.Priority,
+		Seed:             req.Seed,
+		Metadata:         prMetadataFromDTO(req.prMetadataDTO),
+	})
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := suggestReviewersResponse{
+		PR:         prToDTO(suggestion.PR),
+		Candidates: make([]suggestedCandidateDTO, 0, len(suggestion.Candidates)),
+		Seed:       suggestion.Seed,
+	}
+	for _, c := range suggestion.Candidates {
+		resp.Candidates = append(resp.Candidates, suggestedCandidateDTO(c))
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
 // POST /pullRequest/merge
 func (s *Server) handleMergePR(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -452,9 +1231,12 @@ func (s *Server) handleMergePR(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "pull_request_id is required", http.StatusBadRequest)
 		return
 	}
+	if req.Force && !s.requireAdmin(w, r) {
+		return
+	}
 
 	ctx := r.Context()
-	pr, err := s.prs.MergePR(ctx, req.PullRequestID)
+	pr, err := s.prs.MergePR(ctx, req.PullRequestID, req.Force)
 	if err != nil {
 		s.writeDomainError(w, err)
 		return
@@ -481,7 +1263,7 @@ func (s *Server) handleReassign(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := r.Context()
-	pr, newID, err := s.prs.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
+	pr, newID, err := s.prs.ReassignReviewerTo(ctx, req.PullRequestID, req.OldUserID, req.NewUserID)
 	if err != nil {
 		s.writeDomainError(w, err)
 		return
@@ -494,6 +1276,87 @@ func (s *Server) handleReassign(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, http.StatusOK, resp)
 }
 
+// POST /pullRequest/addReviewer
+func (s *Server) handleAddReviewer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req reviewerRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" || req.UserID == "" {
+		http.Error(w, "pull_request_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.AddReviewer(ctx, req.PullRequestID, req.UserID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /pullRequest/removeReviewer
+func (s *Server) handleRemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req reviewerRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" || req.UserID == "" {
+		http.Error(w, "pull_request_id and user_id are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.RemoveReviewer(ctx, req.PullRequestID, req.UserID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /pullRequest/review
+func (s *Server) handleSubmitReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req submitReviewRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" || req.UserID == "" || req.Verdict == "" {
+		http.Error(w, "pull_request_id, user_id and verdict are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.SubmitReview(ctx, req.PullRequestID, req.UserID, req.Verdict, req.Message)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
 // GET /stats/assignments  (доп. задание)
 func (s *Server) handleStatsAssignments(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -502,17 +1365,21 @@ func (s *Server) handleStatsAssignments(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := r.Context()
-	stats, err := s.stats.GetAssignmentsByUser(ctx, s.db)
+	stats, err := s.stats.GetAssignmentShares(ctx, s.db, r.URL.Query().Get("label"))
 	if err != nil {
 		s.writeDomainError(w, err)
 		return
 	}
 
 	out := make([]assignmentsStatItem, 0, len(stats))
-	for uid, cnt := range stats {
+	for _, st := range stats {
 		out = append(out, assignmentsStatItem{
-			UserID: uid,
-			Count:  cnt,
+			UserID:        st.UserID,
+			TeamName:      st.TeamName,
+			Count:         st.Count,
+			ReviewWeight:  st.Weight,
+			Share:         st.Share,
+			ExpectedShare: st.ExpectedShare,
 		})
 	}
 
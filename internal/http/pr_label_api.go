@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"net/http"
+)
+
+type setLabelsRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Labels        []string `json:"labels"`
+}
+
+// POST /pullRequest/labels
+func (s *Server) handleSetLabels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setLabelsRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" || req.Labels == nil {
+		http.Error(w, "pull_request_id and labels are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.SetLabels(ctx, req.PullRequestID, req.Labels)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"net/http"
+)
+
+type setPriorityRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	Priority      string `json:"priority"`
+}
+
+// POST /pullRequest/priority
+func (s *Server) handleSetPriority(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req setPriorityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" || req.Priority == "" {
+		http.Error(w, "pull_request_id and priority are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.SetPriority(ctx, req.PullRequestID, req.Priority)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,90 @@
+package httpapi
+
+import (
+	"net/http"
+)
+
+type prStatusRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// POST /pullRequest/markReady
+func (s *Server) handleMarkReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req prStatusRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" {
+		http.Error(w, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.MarkReady(ctx, req.PullRequestID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /pullRequest/close
+func (s *Server) handleClosePR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req prStatusRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" {
+		http.Error(w, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.ClosePR(ctx, req.PullRequestID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /pullRequest/reopen
+func (s *Server) handleReopenPR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req prStatusRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.PullRequestID == "" {
+		http.Error(w, "pull_request_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := s.prs.ReopenPR(ctx, req.PullRequestID)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := pullRequestResponse{PR: prToDTO(pr)}
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/usecase"
+)
+
+type overdueReviewDTO struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	AssignedAt      time.Time `json:"assignedAt"`
+	DueAt           time.Time `json:"dueAt"`
+	// OverdueSeconds — на сколько секунд срок пропущен на момент запроса.
+	OverdueSeconds int64 `json:"overdue_seconds"`
+}
+
+type overdueReviewerDTO struct {
+	UserID  string             `json:"user_id"`
+	Reviews []overdueReviewDTO `json:"reviews"`
+}
+
+type overdueTeamDTO struct {
+	TeamName  string               `json:"team_name"`
+	Reviewers []overdueReviewerDTO `json:"reviewers"`
+}
+
+type overdueReviewsResponse struct {
+	Teams []overdueTeamDTO `json:"teams"`
+}
+
+func overdueTeamToDTO(t usecase.OverdueTeam) overdueTeamDTO {
+	out := overdueTeamDTO{
+		TeamName:  t.TeamName,
+		Reviewers: make([]overdueReviewerDTO, 0, len(t.Reviewers)),
+	}
+	for _, rv := range t.Reviewers {
+		dto := overdueReviewerDTO{
+			UserID:  rv.UserID,
+			Reviews: make([]overdueReviewDTO, 0, len(rv.Reviews)),
+		}
+		for _, o := range rv.Reviews {
+			dto.Reviews = append(dto.Reviews, overdueReviewDTO{
+				PullRequestID:   o.PRID,
+				PullRequestName: o.PRName,
+				AuthorID:        o.AuthorID,
+				AssignedAt:      o.AssignedAt,
+				DueAt:           o.DueAt,
+				OverdueSeconds:  int64(o.Overdue / time.Second),
+			})
+		}
+		out.Reviewers = append(out.Reviewers, dto)
+	}
+	return out
+}
+
+// GET /reviews/overdue
+func (s *Server) handleOverdueReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	teams, err := s.prs.ListOverdueReviews(r.Context(), s.db)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := overdueReviewsResponse{Teams: make([]overdueTeamDTO, 0, len(teams))}
+	for _, t := range teams {
+		resp.Teams = append(resp.Teams, overdueTeamToDTO(t))
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,131 @@
+package httpapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+type unavailabilityDTO struct {
+	ID       int64     `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type addUnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type deleteUnavailabilityRequest struct {
+	UserID string `json:"user_id"`
+	ID     int64  `json:"id"`
+}
+
+type unavailabilityResponse struct {
+	Unavailability unavailabilityDTO `json:"unavailability"`
+}
+
+type userUnavailabilityResponse struct {
+	UserID  string              `json:"user_id"`
+	Periods []unavailabilityDTO `json:"periods"`
+}
+
+func unavailabilityToDTO(u domain.Unavailability) unavailabilityDTO {
+	return unavailabilityDTO{
+		ID:       u.ID,
+		UserID:   u.UserID,
+		StartsAt: u.StartsAt,
+		EndsAt:   u.EndsAt,
+		Reason:   u.Reason,
+	}
+}
+
+func (s *Server) writeUserUnavailability(w http.ResponseWriter, r *http.Request, userID string) {
+	periods, err := s.users.ListUnavailability(r.Context(), s.db, userID, time.Now())
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	resp := userUnavailabilityResponse{
+		UserID:  userID,
+		Periods: make([]unavailabilityDTO, 0, len(periods)),
+	}
+	for _, p := range periods {
+		resp.Periods = append(resp.Periods, unavailabilityToDTO(p))
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// POST /users/addUnavailability
+func (s *Server) handleAddUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req addUnavailabilityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" || req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		http.Error(w, "user_id, starts_at and ends_at are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	period, err := s.users.AddUnavailability(ctx, s.db, req.UserID, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusCreated, unavailabilityResponse{Unavailability: unavailabilityToDTO(*period)})
+}
+
+// GET /users/getUnavailability?user_id=...
+func (s *Server) handleGetUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	s.writeUserUnavailability(w, r, userID)
+}
+
+// POST /users/deleteUnavailability
+func (s *Server) handleDeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req deleteUnavailabilityRequest
+	if !s.decodeJSON(w, r, &req) {
+		return
+	}
+	if req.UserID == "" || req.ID == 0 {
+		http.Error(w, "user_id and id are required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.users.DeleteUnavailability(ctx, s.db, req.UserID, req.ID); err != nil {
+		s.writeDomainError(w, err)
+		return
+	}
+
+	s.writeUserUnavailability(w, r, req.UserID)
+}
@@ -27,6 +27,13 @@ type Config struct {
 	UserTokens        []string
 	RequestTimeout    time.Duration
 	ReadHeaderTimeout time.Duration
+
+	ReviewerStrategy       string
+	TeamReviewerStrategies map[string]string
+
+	// StaleReviewInterval — период фонового переназначения зависших ревью (0 — выключено).
+	StaleReviewInterval time.Duration
+	StaleReviewDryRun   bool
 }
 
 func Load() (*Config, error) {
@@ -95,6 +102,23 @@ func Load() (*Config, error) {
 	cfg.AdminTokens = parseCSV(os.Getenv("ADMIN_TOKENS"))
 	cfg.UserTokens = parseCSV(os.Getenv("USER_TOKENS"))
 
+	cfg.ReviewerStrategy = strings.ToLower(strings.TrimSpace(getEnv("REVIEWER_STRATEGY", "random")))
+	teamStrategies, err := parseKV(os.Getenv("TEAM_REVIEWER_STRATEGIES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TEAM_REVIEWER_STRATEGIES: %w", err)
+	}
+	cfg.TeamReviewerStrategies = teamStrategies
+
+	staleIntervalSec := parseIntWithDefault(getEnv("STALE_REVIEW_INTERVAL_SEC", "0"), 0)
+	cfg.StaleReviewInterval = time.Duration(staleIntervalSec) * time.Second
+
+	dryRunStr := getEnv("STALE_REVIEW_DRY_RUN", "false")
+	dryRun, err := strconv.ParseBool(strings.TrimSpace(dryRunStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid STALE_REVIEW_DRY_RUN %q: %w", dryRunStr, err)
+	}
+	cfg.StaleReviewDryRun = dryRun
+
 	return cfg, nil
 }
 
@@ -128,3 +152,18 @@ func parseCSV(s string) []string {
 	}
 	return out
 }
+
+// parseKV разбирает строку вида "backend:least_loaded,frontend:round_robin".
+func parseKV(s string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, p := range parseCSV(s) {
+		k, v, ok := strings.Cut(p, ":")
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("bad pair %q, expected key:value", p)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
@@ -49,3 +49,33 @@ func WithTx(
 	retErr = fn(ctx, tx)
 	return retErr
 }
+
+// WithConn выполняет fn на отдельном соединении пула вне транзакции. Если fn вернула
+// ошибку, соединение закрывается, а не возвращается в пул: так на нём не остаётся
+// сессионного состояния (например, advisory-блокировок).
+func WithConn(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	logger log.Logger,
+	fn func(ctx context.Context, conn *pgxpool.Conn) error,
+) error {
+	if pool == nil {
+		return fmt.Errorf("db.WithConn: pool is nil")
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		logger.Error("conn_acquire_failed", "err", err)
+		return fmt.Errorf("acquire conn: %w", err)
+	}
+
+	if err := fn(ctx, conn); err != nil {
+		if clErr := conn.Hijack().Close(context.WithoutCancel(ctx)); clErr != nil {
+			logger.Error("conn_close_failed", "err", clErr)
+		}
+		return err
+	}
+
+	conn.Release()
+	return nil
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// Раунды и исключённые кандидаты хранятся в JSONB; формат задают эти структуры.
+type roundJSON struct {
+	Note       string          `json:"note,omitempty"`
+	Count      int             `json:"count"`
+	Assigned   []string        `json:"assigned,omitempty"`
+	Candidates []candidateJSON `json:"candidates"`
+	Picked     []string        `json:"picked"`
+}
+
+type candidateJSON struct {
+	UserID         string     `json:"user_id"`
+	OpenReviews    int        `json:"open_reviews"`
+	LastAssignedAt *time.Time `json:"last_assigned_at,omitempty"`
+	PairScore      float64    `json:"pair_score,omitempty"`
+	Weight         int        `json:"weight"`
+}
+
+type excludedJSON struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+func (r *PRRepo) AddAssignmentExplanation(
+	ctx context.Context,
+	db repository.DBExecutor,
+	e *domain.AssignmentExplanation,
+) error {
+	const q = `
+INSERT INTO pr_assignment_explanations (pr_id, kind, strategy, seed, reviewers, replaced_reviewer, rounds, excluded, reason, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id;
+`
+
+	rounds := make([]roundJSON, 0, len(e.Rounds))
+	for _, round := range e.Rounds {
+		rj := roundJSON{
+			Note:       round.Note,
+			Count:      round.Count,
+			Assigned:   round.Assigned,
+			Candidates: make([]candidateJSON, 0, len(round.Candidates)),
+			Picked:     nonNilStrings(round.Picked),
+		}
+		for _, c := range round.Candidates {
+			rj.Candidates = append(rj.Candidates, candidateJSON(c))
+		}
+		rounds = append(rounds, rj)
+	}
+	excluded := make([]excludedJSON, 0, len(e.Excluded))
+	for _, ex := range e.Excluded {
+		excluded = append(excluded, excludedJSON(ex))
+	}
+
+	createdAt := e.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	err := db.QueryRow(ctx, q,
+		e.PRID,
+		string(e.Kind),
+		e.Strategy,
+		e.Seed,
+		nonNilStrings(e.Reviewers),
+		nullIfEmpty(e.ReplacedReviewer),
+		rounds,
+		excluded,
+		e.Reason,
+		createdAt,
+	).Scan(&e.ID)
+	if err != nil {
+		r.Logger.Error("pr_add_assignment_explanation_failed", "pr_id", e.PRID, "err", err)
+		return fmt.Errorf("add assignment explanation for pr %q: %w", e.PRID, err)
+	}
+
+	return nil
+}
+
+func (r *PRRepo) ListAssignmentExplanations(
+	ctx context.Context,
+	db repository.DBExecutor,
+	prID string,
+) ([]domain.AssignmentExplanation, error) {
+	const q = `
+SELECT id, pr_id, kind, strategy, seed, reviewers, COALESCE(replaced_reviewer, ''), rounds, excluded, reason, created_at
+FROM pr_assignment_explanations
+WHERE pr_id = $1
+ORDER BY id;
+`
+
+	rows, err := db.Query(ctx, q, prID)
+	if err != nil {
+		r.Logger.Error("pr_list_assignment_explanations_failed", "pr_id", prID, "err", err)
+		return nil, fmt.Errorf("list assignment explanations for pr %q: %w", prID, err)
+	}
+	defer rows.Close()
+
+	res := make([]domain.AssignmentExplanation, 0)
+
+	for rows.Next() {
+		var (
+			e        domain.AssignmentExplanation
+			kind     string
+			rounds   []roundJSON
+			excluded []excludedJSON
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.PRID,
+			&kind,
+			&e.Strategy,
+			&e.Seed,
+			&e.Reviewers,
+			&e.ReplacedReviewer,
+			&rounds,
+			&excluded,
+			&e.Reason,
+			&e.CreatedAt,
+		); err != nil {
+			r.Logger.Error("pr_list_assignment_explanations_scan_failed", "pr_id", prID, "err", err)
+			return nil, fmt.Errorf("scan assignment explanation for pr %q: %w", prID, err)
+		}
+		e.Kind = domain.AssignmentKind(kind)
+
+		for _, rj := range rounds {
+			round := domain.SelectionRound{
+				Note:       rj.Note,
+				Count:      rj.Count,
+				Assigned:   rj.Assigned,
+				Candidates: make([]domain.CandidateSnapshot, 0, len(rj.Candidates)),
+				Picked:     rj.Picked,
+			}
+			for _, c := range rj.Candidates {
+				round.Candidates = append(round.Candidates, domain.CandidateSnapshot(c))
+			}
+			e.Rounds = append(e.Rounds, round)
+		}
+		for _, ex := range excluded {
+			e.Excluded = append(e.Excluded, domain.SkippedReviewer(ex))
+		}
+
+		res = append(res, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_list_assignment_explanations_rows_err", "pr_id", prID, "err", err)
+		return nil, fmt.Errorf("iterate assignment explanations for pr %q: %w", prID, err)
+	}
+
+	return res, nil
+}
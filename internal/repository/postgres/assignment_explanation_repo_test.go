@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+func TestPRRepo_AssignmentExplanations_RoundTrip(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	lastAssigned := time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC)
+	in := &domain.AssignmentExplanation{
+		PRID:      "pr-1",
+		Kind:      domain.AssignmentKindCreate,
+		Strategy:  "random",
+		Seed:      -42,
+		Reviewers: []string{"u2"},
+		Rounds: []domain.SelectionRound{{
+			Count: 2,
+			Candidates: []domain.CandidateSnapshot{
+				{UserID: "u2", OpenReviews: 3, LastAssignedAt: &lastAssigned},
+			},
+			Picked: []string{"u2"},
+		}},
+		Excluded: []domain.SkippedReviewer{{UserID: "u1", Reason: "author"}},
+		Reason:   "random choice among 1 candidates",
+	}
+	if err := repo.AddAssignmentExplanation(ctx, testPool, in); err != nil {
+		t.Fatalf("AddAssignmentExplanation() error = %v", err)
+	}
+	if in.ID == 0 {
+		t.Fatalf("expected explanation id to be set")
+	}
+
+	got, err := repo.ListAssignmentExplanations(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("ListAssignmentExplanations() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("explanations len = %d, want 1", len(got))
+	}
+
+	e := got[0]
+	if e.Seed != -42 || e.Strategy != "random" || e.Kind != domain.AssignmentKindCreate || e.ReplacedReviewer != "" {
+		t.Errorf("unexpected explanation: %+v", e)
+	}
+	if len(e.Rounds) != 1 || len(e.Rounds[0].Candidates) != 1 {
+		t.Fatalf("unexpected rounds: %+v", e.Rounds)
+	}
+	c := e.Rounds[0].Candidates[0]
+	if c.UserID != "u2" || c.OpenReviews != 3 || c.LastAssignedAt == nil || !c.LastAssignedAt.Equal(lastAssigned) {
+		t.Errorf("unexpected candidate snapshot: %+v", c)
+	}
+	if len(e.Excluded) != 1 || e.Excluded[0].Reason != "author" {
+		t.Errorf("unexpected excluded: %+v", e.Excluded)
+	}
+}
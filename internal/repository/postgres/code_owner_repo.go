@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+type CodeOwnerRepo struct {
+	Logger log.Logger
+}
+
+func NewCodeOwnerRepo(logger log.Logger) repository.CodeOwnerRepository {
+	return &CodeOwnerRepo{
+		Logger: logger,
+	}
+}
+
+func (r *CodeOwnerRepo) AddRule(ctx context.Context, db repository.DBExecutor, rule *domain.CodeOwnerRule) error {
+	const q = `
+INSERT INTO code_owner_rules (pattern, owner_teams, owner_users, source)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+`
+
+	err := db.QueryRow(ctx, q,
+		rule.Pattern,
+		nonNilStrings(rule.Teams),
+		nonNilStrings(rule.Users),
+		string(rule.Source),
+	).Scan(&rule.ID)
+	if err != nil {
+		r.Logger.Error("code_owner_add_failed", "pattern", rule.Pattern, "err", err)
+		return fmt.Errorf("add code owner rule %q: %w", rule.Pattern, err)
+	}
+
+	return nil
+}
+
+func (r *CodeOwnerRepo) ListRules(ctx context.Context, db repository.DBExecutor) ([]domain.CodeOwnerRule, error) {
+	const q = `
+SELECT id, pattern, owner_teams, owner_users, source
+FROM code_owner_rules
+ORDER BY source = 'manual', id;
+`
+
+	rows, err := db.Query(ctx, q)
+	if err != nil {
+		r.Logger.Error("code_owner_list_failed", "err", err)
+		return nil, fmt.Errorf("list code owner rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]domain.CodeOwnerRule, 0)
+
+	for rows.Next() {
+		var (
+			rule   domain.CodeOwnerRule
+			source string
+		)
+		if err := rows.Scan(&rule.ID, &rule.Pattern, &rule.Teams, &rule.Users, &source); err != nil {
+			r.Logger.Error("code_owner_list_scan_failed", "err", err)
+			return nil, fmt.Errorf("scan code owner rule: %w", err)
+		}
+		rule.Source = domain.CodeOwnerSource(source)
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("code_owner_list_rows_err", "err", err)
+		return nil, fmt.Errorf("iterate code owner rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *CodeOwnerRepo) ReplaceRulesBySource(
+	ctx context.Context,
+	db repository.DBExecutor,
+	source domain.CodeOwnerSource,
+	rules []domain.CodeOwnerRule,
+) error {
+	const del = `DELETE FROM code_owner_rules WHERE source = $1;`
+
+	if _, err := db.Exec(ctx, del, string(source)); err != nil {
+		r.Logger.Error("code_owner_delete_by_source_failed", "source", source, "err", err)
+		return fmt.Errorf("delete code owner rules from %q: %w", source, err)
+	}
+
+	for i := range rules {
+		rules[i].Source = source
+		if err := r.AddRule(ctx, db, &rules[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
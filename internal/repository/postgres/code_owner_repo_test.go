@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+func newCodeOwnerRepo() *CodeOwnerRepo {
+	return &CodeOwnerRepo{
+		Logger: testLogger,
+	}
+}
+
+func TestCodeOwnerRepo_ManualRulesOverrideImported(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newCodeOwnerRepo()
+
+	manual, err := domain.NewCodeOwnerRule("/lib/", []string{"platform"}, nil, domain.CodeOwnerSourceManual)
+	if err != nil {
+		t.Fatalf("NewCodeOwnerRule() error = %v", err)
+	}
+	if err := repo.AddRule(ctx, testPool, manual); err != nil {
+		t.Fatalf("AddRule() error = %v", err)
+	}
+	if manual.ID == 0 {
+		t.Fatalf("expected rule id to be set")
+	}
+
+	imported, _ := domain.ParseCodeOwners("* @acme/backend\n/docs/ @alice\n")
+	if err := repo.ReplaceRulesBySource(ctx, testPool, domain.CodeOwnerSourceCodeOwners, imported); err != nil {
+		t.Fatalf("ReplaceRulesBySource() error = %v", err)
+	}
+
+	// повторный импорт заменяет только импортированные правила
+	imported, _ = domain.ParseCodeOwners("* @acme/frontend\n")
+	if err := repo.ReplaceRulesBySource(ctx, testPool, domain.CodeOwnerSourceCodeOwners, imported); err != nil {
+		t.Fatalf("ReplaceRulesBySource() error = %v", err)
+	}
+
+	rules, err := repo.ListRules(ctx, testPool)
+	if err != nil {
+		t.Fatalf("ListRules() error = %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("rules len = %d, want 2: %+v", len(rules), rules)
+	}
+	if rules[0].Pattern != "*" || rules[0].Teams[0] != "frontend" || rules[0].Source != domain.CodeOwnerSourceCodeOwners {
+		t.Errorf("unexpected imported rule: %+v", rules[0])
+	}
+	if rules[1].Pattern != "/lib/" || rules[1].Source != domain.CodeOwnerSourceManual {
+		t.Errorf("unexpected manual rule: %+v", rules[1])
+	}
+}
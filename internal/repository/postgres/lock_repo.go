@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+type LockRepo struct {
+	Logger log.Logger
+}
+
+func NewLockRepo(logger log.Logger) repository.LockRepository {
+	return &LockRepo{
+		Logger: logger,
+	}
+}
+
+// TryLock не ждёт блокировку: если её держит другая реплика, сразу возвращает false.
+func (r *LockRepo) TryLock(ctx context.Context, db repository.DBExecutor, key int64) (bool, error) {
+	const q = `SELECT pg_try_advisory_lock($1);`
+
+	var locked bool
+	if err := db.QueryRow(ctx, q, key).Scan(&locked); err != nil {
+		r.Logger.Error("advisory_lock_failed", "key", key, "err", err)
+		return false, fmt.Errorf("try advisory lock %d: %w", key, err)
+	}
+	return locked, nil
+}
+
+func (r *LockRepo) Unlock(ctx context.Context, db repository.DBExecutor, key int64) error {
+	const q = `SELECT pg_advisory_unlock($1);`
+
+	var unlocked bool
+	if err := db.QueryRow(ctx, q, key).Scan(&unlocked); err != nil {
+		r.Logger.Error("advisory_unlock_failed", "key", key, "err", err)
+		return fmt.Errorf("advisory unlock %d: %w", key, err)
+	}
+	if !unlocked {
+		r.Logger.Warn("advisory_unlock_not_held", "key", key)
+		return fmt.Errorf("advisory lock %d is not held", key)
+	}
+	return nil
+}
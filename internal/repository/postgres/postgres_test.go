@@ -52,6 +52,7 @@ TRUNCATE TABLE pr_reviewers RESTART IDENTITY CASCADE;
 TRUNCATE TABLE prs RESTART IDENTITY CASCADE;
 TRUNCATE TABLE users RESTART IDENTITY CASCADE;
 TRUNCATE TABLE teams RESTART IDENTITY CASCADE;
+TRUNCATE TABLE code_owner_rules RESTART IDENTITY CASCADE;
 `)
 	if err != nil {
 		t.Fatalf("failed to truncate tables: %v", err)
@@ -26,8 +26,9 @@ func NewPRRepo(logger log.Logger) repository.PRRepository {
 
 func (r *PRRepo) CreatePR(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest) error {
 	const q = `
-INSERT INTO prs (pr_id, pr_name, author_id, status, created_at, merged_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO prs (pr_id, pr_name, author_id, status, reviewers_required, required_skills, changed_files, excluded_reviewers, created_at, merged_at,
+                 repository, source_branch, target_branch, url, description, lines_added, lines_deleted, files_changed, priority)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19);
 `
 
 	var mergedAt any
@@ -42,8 +43,21 @@ VALUES ($1, $2, $3, $4, $5, $6);
 		pr.Name,
 		pr.AuthorID,
 		string(pr.Status),
+		pr.MaxReviewers(),
+		nonNilStrings(pr.RequiredSkills),
+		nonNilStrings(pr.ChangedFiles),
+		nonNilStrings(pr.ExcludedReviewers),
 		pr.CreatedAt,
 		mergedAt,
+		pr.Metadata.Repository,
+		pr.Metadata.SourceBranch,
+		pr.Metadata.TargetBranch,
+		pr.Metadata.URL,
+		pr.Metadata.Description,
+		pr.Metadata.LinesAdded,
+		pr.Metadata.LinesDeleted,
+		pr.Metadata.FilesChanged,
+		string(pr.Priority),
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -57,23 +71,55 @@ VALUES ($1, $2, $3, $4, $5, $6);
 	return nil
 }
 
+const prColumns = `pr_id, pr_name, author_id, status, reviewers_required, required_skills, changed_files, excluded_reviewers, created_at, merged_at, closed_at,
+    repository, source_branch, target_branch, url, description, lines_added, lines_deleted, files_changed, priority`
+
+func scanPR(row pgx.Row) (*domain.PullRequest, error) {
+	var (
+		pr          domain.PullRequest
+		statusStr   string
+		priorityStr string
+	)
+
+	err := row.Scan(
+		&pr.ID,
+		&pr.Name,
+		&pr.AuthorID,
+		&statusStr,
+		&pr.ReviewersRequired,
+		&pr.RequiredSkills,
+		&pr.ChangedFiles,
+		&pr.ExcludedReviewers,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+		&pr.ClosedAt,
+		&pr.Metadata.Repository,
+		&pr.Metadata.SourceBranch,
+		&pr.Metadata.TargetBranch,
+		&pr.Metadata.URL,
+		&pr.Metadata.Description,
+		&pr.Metadata.LinesAdded,
+		&pr.Metadata.LinesDeleted,
+		&pr.Metadata.FilesChanged,
+		&priorityStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pr.Status = domain.PRStatus(statusStr)
+	pr.Priority = domain.PRPriority(priorityStr)
+
+	return &pr, nil
+}
+
 func (r *PRRepo) GetPRByID(ctx context.Context, db repository.DBExecutor, prID string) (*domain.PullRequest, []string, error) {
-	const qPR = `
-SELECT pr_id, pr_name, author_id, status, created_at, merged_at
+	qPR := `
+SELECT ` + prColumns + `
 FROM prs
 WHERE pr_id = $1;
 `
 
-	var (
-		id        string
-		name      string
-		authorID  string
-		statusStr string
-		createdAt time.Time
-		mergedAt  *time.Time
-	)
-
-	err := db.QueryRow(ctx, qPR, prID).Scan(&id, &name, &authorID, &statusStr, &createdAt, &mergedAt)
+	pr, err := scanPR(db.QueryRow(ctx, qPR, prID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
@@ -82,43 +128,28 @@ WHERE pr_id = $1;
 		return nil, nil, fmt.Errorf("get pr %q: %w", prID, err)
 	}
 
-	pr := &domain.PullRequest{
-		ID:                id,
-		Name:              name,
-		AuthorID:          authorID,
-		Status:            domain.PRStatus(statusStr),
-		AssignedReviewers: nil, // заполним ниже
-		CreatedAt:         createdAt,
-		MergedAt:          mergedAt,
+	if err := r.loadReviewers(ctx, db, pr); err != nil {
+		return nil, nil, err
 	}
-
-	reviewers, err := r.getReviewers(ctx, db, prID)
-	if err != nil {
+	if err := r.loadReviews(ctx, db, pr); err != nil {
+		return nil, nil, err
+	}
+	if err := r.loadLabels(ctx, db, pr); err != nil {
 		return nil, nil, err
 	}
-	pr.AssignedReviewers = reviewers
 
-	return pr, reviewers, nil
+	return pr, pr.AssignedReviewers, nil
 }
 
 func (r *PRRepo) GetPRForUpdate(ctx context.Context, db repository.DBExecutor, prID string) (*domain.PullRequest, []string, error) {
-	const qPR = `
-SELECT pr_id, pr_name, author_id, status, created_at, merged_at
+	qPR := `
+SELECT ` + prColumns + `
 FROM prs
 WHERE pr_id = $1
 FOR UPDATE;
 `
 
-	var (
-		id        string
-		name      string
-		authorID  string
-		statusStr string
-		createdAt time.Time
-		mergedAt  *time.Time
-	)
-
-	err := db.QueryRow(ctx, qPR, prID).Scan(&id, &name, &authorID, &statusStr, &createdAt, &mergedAt)
+	pr, err := scanPR(db.QueryRow(ctx, qPR, prID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
@@ -127,23 +158,17 @@ FOR UPDATE;
 		return nil, nil, fmt.Errorf("get pr for update %q: %w", prID, err)
 	}
 
-	pr := &domain.PullRequest{
-		ID:                id,
-		Name:              name,
-		AuthorID:          authorID,
-		Status:            domain.PRStatus(statusStr),
-		AssignedReviewers: nil,
-		CreatedAt:         createdAt,
-		MergedAt:          mergedAt,
+	if err := r.loadReviewers(ctx, db, pr); err != nil {
+		return nil, nil, err
 	}
-
-	reviewers, err := r.getReviewers(ctx, db, prID)
-	if err != nil {
+	if err := r.loadReviews(ctx, db, pr); err != nil {
+		return nil, nil, err
+	}
+	if err := r.loadLabels(ctx, db, pr); err != nil {
 		return nil, nil, err
 	}
-	pr.AssignedReviewers = reviewers
 
-	return pr, reviewers, nil
+	return pr, pr.AssignedReviewers, nil
 }
 
 func (r *PRRepo) SetMerged(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest) error {
@@ -169,37 +194,175 @@ WHERE pr_id = $3;
 	return nil
 }
 
-func (r *PRRepo) AssignReviewers(ctx context.Context, db repository.DBExecutor, prID string, reviewerIDs []string) error {
-	if len(reviewerIDs) == 0 {
+// SetStatus сохраняет статус PR и время закрытия (DRAFT, OPEN, CLOSED; для MERGED — SetMerged).
+func (r *PRRepo) SetStatus(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest) error {
+	const q = `
+UPDATE prs
+SET status = $1, closed_at = $2, reviewers_required = $4
+WHERE pr_id = $3;
+`
+
+	tag, err := db.Exec(ctx, q, string(pr.Status), pr.ClosedAt, pr.ID, pr.MaxReviewers())
+	if err != nil {
+		r.Logger.Error("pr_set_status_failed", "pr_id", pr.ID, "status", pr.Status, "err", err)
+		return fmt.Errorf("set status of pr %q: %w", pr.ID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
+	}
+
+	return nil
+}
+
+// ReleaseReviewers снимает всех ревьюверов PR; назначения и вердикты остаются в истории.
+func (r *PRRepo) ReleaseReviewers(ctx context.Context, db repository.DBExecutor, prID string, at time.Time) error {
+	const q = `UPDATE pr_reviewers SET released_at = $2 WHERE pr_id = $1 AND released_at IS NULL;`
+
+	if _, err := db.Exec(ctx, q, prID, at); err != nil {
+		r.Logger.Error("pr_release_reviewers_failed", "pr_id", prID, "err", err)
+		return fmt.Errorf("release reviewers of pr %q: %w", prID, err)
+	}
+
+	return nil
+}
+
+func (r *PRRepo) SetPriority(ctx context.Context, db repository.DBExecutor, prID string, priority domain.PRPriority) error {
+	const q = `UPDATE prs SET priority = $1 WHERE pr_id = $2;`
+
+	tag, err := db.Exec(ctx, q, string(priority), prID)
+	if err != nil {
+		r.Logger.Error("pr_set_priority_failed", "pr_id", prID, "priority", priority, "err", err)
+		return fmt.Errorf("set priority of pr %q: %w", prID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
+	}
+
+	return nil
+}
+
+func (r *PRRepo) SetLabels(ctx context.Context, db repository.DBExecutor, prID string, labels []string) error {
+	const qDelete = `DELETE FROM pr_labels WHERE pr_id = $1;`
+	const qInsert = `
+INSERT INTO pr_labels (pr_id, label)
+SELECT $1, unnest($2::text[]);
+`
+
+	if _, err := db.Exec(ctx, qDelete, prID); err != nil {
+		r.Logger.Error("pr_clear_labels_failed", "pr_id", prID, "err", err)
+		return fmt.Errorf("clear labels of pr %q: %w", prID, err)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	if _, err := db.Exec(ctx, qInsert, prID, labels); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
+		}
+		r.Logger.Error("pr_set_labels_failed", "pr_id", prID, "err", err)
+		return fmt.Errorf("set labels of pr %q: %w", prID, err)
+	}
+
+	return nil
+}
+
+func (r *PRRepo) AssignReviewers(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest, at time.Time) error {
+	if len(pr.AssignedReviewers) == 0 {
 		return nil
 	}
 
 	const q = `
-INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
-VALUES ($1, $2, $3, now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, fallback_team, assigned_at, due_at)
+VALUES ($1, $2, $3, $4, $6, $5);
 `
 
-	for i, userID := range reviewerIDs {
-		slot := i + 1 
-		_, err := db.Exec(ctx, q, prID, userID, slot)
+	for i, userID := range pr.AssignedReviewers {
+		slot := i
+		var dueAt *time.Time
+		if due, ok := pr.ReviewDueAt[userID]; ok {
+			dueAt = &due
+		}
+		_, err := db.Exec(ctx, q, pr.ID, userID, slot, nullIfEmpty(pr.FallbackReviewers[userID]), dueAt, at)
 		if err != nil {
-			r.Logger.Error("pr_assign_reviewer_failed", "pr_id", prID, "user_id", userID, "slot", slot, "err", err)
-			return fmt.Errorf("assign reviewers for pr %q: %w", prID, err)
+			r.Logger.Error("pr_assign_reviewer_failed", "pr_id", pr.ID, "user_id", userID, "slot", slot, "err", err)
+			return fmt.Errorf("assign reviewers for pr %q: %w", pr.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PRRepo) AddReviewer(ctx context.Context, db repository.DBExecutor, prID, userID, fallbackTeam string, dueAt *time.Time, at time.Time) error {
+	const q = `
+INSERT INTO pr_reviewers (pr_id, user_id, slot, fallback_team, assigned_at, due_at)
+SELECT $1, $2, MIN(s.slot), $3, $5, $4
+FROM generate_series(0, 9) AS s(slot)
+WHERE NOT EXISTS (SELECT 1 FROM pr_reviewers r WHERE r.pr_id = $1 AND r.slot = s.slot AND r.released_at IS NULL);
+`
+
+	_, err := db.Exec(ctx, q, prID, userID, nullIfEmpty(fallbackTeam), dueAt, at)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "pr or user not found")
 		}
+		r.Logger.Error("pr_add_reviewer_failed", "pr_id", prID, "user_id", userID, "err", err)
+		return fmt.Errorf("add reviewer %q to pr %q: %w", userID, prID, err)
 	}
 
 	return nil
 }
 
-func (r *PRRepo) ReplaceReviewer(ctx context.Context, db repository.DBExecutor, prID string, oldID, newID string) error {
+func (r *PRRepo) RemoveReviewer(ctx context.Context, db repository.DBExecutor, prID, userID string, at time.Time) error {
 	const q = `
 UPDATE pr_reviewers
-SET user_id = $1, assigned_at = now()
-WHERE pr_id = $2
-  AND user_id = $3;
+SET released_at = $3
+WHERE pr_id = $1
+  AND user_id = $2
+  AND released_at IS NULL;
 `
 
-	tag, err := db.Exec(ctx, q, newID, prID, oldID)
+	tag, err := db.Exec(ctx, q, prID, userID, at)
+	if err != nil {
+		r.Logger.Error("pr_remove_reviewer_failed", "pr_id", prID, "user_id", userID, "err", err)
+		return fmt.Errorf("remove reviewer %q from pr %q: %w", userID, prID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+	}
+
+	return nil
+}
+
+// ReplaceReviewer снимает oldID и назначает newID в его слот; назначение oldID остаётся в истории.
+func (r *PRRepo) ReplaceReviewer(
+	ctx context.Context,
+	db repository.DBExecutor,
+	prID string,
+	oldID, newID string,
+	fallbackTeam string,
+	dueAt *time.Time,
+	at time.Time,
+) error {
+	const q = `
+WITH released AS (
+    UPDATE pr_reviewers
+    SET released_at = $6
+    WHERE pr_id = $2
+      AND user_id = $3
+      AND released_at IS NULL
+    RETURNING slot
+)
+INSERT INTO pr_reviewers (pr_id, user_id, slot, fallback_team, assigned_at, due_at)
+SELECT $2, $1, slot, $4, $6, $5
+FROM released;
+`
+
+	tag, err := db.Exec(ctx, q, newID, prID, oldID, nullIfEmpty(fallbackTeam), dueAt, at)
 	if err != nil {
 		r.Logger.Error("pr_replace_reviewer_failed", "pr_id", prID, "old_id", oldID, "new_id", newID, "err", err)
 		return fmt.Errorf("replace reviewer for pr %q: %w", prID, err)
@@ -212,15 +375,21 @@ WHERE pr_id = $2
 	return nil
 }
 
-func (r *PRRepo) ListPRsByReviewer(ctx context.Context, db repository.DBExecutor, userID string) ([]domain.PullRequest, error) {
+func (r *PRRepo) ListPRsByReviewer(ctx context.Context, db repository.DBExecutor, userID, label string) ([]domain.PullRequest, error) {
 	const q = `
-SELECT p.pr_id, p.pr_name, p.author_id, p.status
+SELECT p.pr_id, p.pr_name, p.author_id, p.status, p.priority, p.created_at,
+       COALESCE((SELECT array_agg(l.label ORDER BY l.label) FROM pr_labels l WHERE l.pr_id = p.pr_id), '{}'),
+       p.repository, p.source_branch, p.target_branch, p.url, p.description, p.lines_added, p.lines_deleted, p.files_changed
 FROM prs p
 JOIN pr_reviewers r ON r.pr_id = p.pr_id
-WHERE r.user_id = $1;
+WHERE r.user_id = $1
+  AND r.released_at IS NULL
+  AND ($2 = '' OR EXISTS (SELECT 1 FROM pr_labels l WHERE l.pr_id = p.pr_id AND l.label = $2))
+ORDER BY CASE p.priority WHEN 'hotfix' THEN 0 WHEN 'high' THEN 1 WHEN 'normal' THEN 2 ELSE 3 END,
+         p.created_at, p.pr_id;
 `
 
-	rows, err := db.Query(ctx, q, userID)
+	rows, err := db.Query(ctx, q, userID, label)
 	if err != nil {
 		r.Logger.Error("pr_list_by_reviewer_failed", "user_id", userID, "err", err)
 		return nil, fmt.Errorf("list prs by reviewer %q: %w", userID, err)
@@ -231,22 +400,32 @@ WHERE r.user_id = $1;
 
 	for rows.Next() {
 		var (
-			id        string
-			name      string
-			authorID  string
-			statusStr string
+			id          string
+			name        string
+			authorID    string
+			statusStr   string
+			priorityStr string
+			createdAt   time.Time
+			labels      []string
+			metadata    domain.PRMetadata
 		)
 
-		if err := rows.Scan(&id, &name, &authorID, &statusStr); err != nil {
+		if err := rows.Scan(&id, &name, &authorID, &statusStr, &priorityStr, &createdAt, &labels,
+			&metadata.Repository, &metadata.SourceBranch, &metadata.TargetBranch, &metadata.URL, &metadata.Description,
+			&metadata.LinesAdded, &metadata.LinesDeleted, &metadata.FilesChanged); err != nil {
 			r.Logger.Error("pr_list_by_reviewer_scan_failed", "user_id", userID, "err", err)
 			return nil, fmt.Errorf("scan prs by reviewer %q: %w", userID, err)
 		}
 
 		pr := domain.PullRequest{
-			ID:       id,
-			Name:     name,
-			AuthorID: authorID,
-			Status:   domain.PRStatus(statusStr),
+			ID:        id,
+			Name:      name,
+			AuthorID:  authorID,
+			Status:    domain.PRStatus(statusStr),
+			Priority:  domain.PRPriority(priorityStr),
+			Labels:    labels,
+			CreatedAt: createdAt,
+			Metadata:  metadata,
 		}
 		res = append(res, pr)
 	}
@@ -259,10 +438,107 @@ WHERE r.user_id = $1;
 	return res, nil
 }
 
+func (r *PRRepo) ListOverdueReviews(ctx context.Context, db repository.DBExecutor, now time.Time) ([]repository.OverdueReview, error) {
+	const q = `
+SELECT p.pr_id, p.pr_name, p.author_id, r.user_id, u.team_name, r.assigned_at, r.due_at
+FROM pr_reviewers r
+JOIN prs p ON p.pr_id = r.pr_id
+JOIN users u ON u.user_id = r.user_id
+WHERE p.status = 'OPEN'
+  AND r.released_at IS NULL
+  AND r.due_at < $1
+  AND NOT EXISTS (
+      SELECT 1 FROM pr_reviews v
+      WHERE v.pr_id = r.pr_id AND v.user_id = r.user_id AND v.submitted_at >= r.assigned_at
+  )
+ORDER BY u.team_name, r.user_id, r.due_at, p.pr_id;
+`
+
+	rows, err := db.Query(ctx, q, now)
+	if err != nil {
+		r.Logger.Error("pr_list_overdue_reviews_failed", "err", err)
+		return nil, fmt.Errorf("list overdue reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var res []repository.OverdueReview
+
+	for rows.Next() {
+		var o repository.OverdueReview
+		if err := rows.Scan(&o.PRID, &o.PRName, &o.AuthorID, &o.ReviewerID, &o.TeamName, &o.AssignedAt, &o.DueAt); err != nil {
+			r.Logger.Error("pr_list_overdue_reviews_scan_failed", "err", err)
+			return nil, fmt.Errorf("scan overdue reviews: %w", err)
+		}
+		res = append(res, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_list_overdue_reviews_rows_err", "err", err)
+		return nil, fmt.Errorf("iterate overdue reviews: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *PRRepo) ListStaleReviews(ctx context.Context, db repository.DBExecutor, now time.Time) ([]repository.StaleReview, error) {
+	const q = `
+SELECT r.pr_id, r.user_id, r.assigned_at, NOT u.is_active
+FROM pr_reviewers r
+JOIN prs p ON p.pr_id = r.pr_id
+JOIN users u ON u.user_id = r.user_id
+JOIN users a ON a.user_id = p.author_id
+LEFT JOIN teams t ON t.team_name = a.team_name
+WHERE p.status = 'OPEN'
+  AND r.released_at IS NULL
+  AND (
+      NOT u.is_active
+      OR (COALESCE(t.stale_review_hours, 0) > 0
+          AND r.assigned_at <= $1::timestamptz - make_interval(hours => t.stale_review_hours))
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM pr_reviews v
+      WHERE v.pr_id = r.pr_id AND v.user_id = r.user_id AND v.submitted_at >= r.assigned_at
+  )
+ORDER BY r.assigned_at, r.pr_id, r.user_id;
+`
+
+	rows, err := db.Query(ctx, q, now)
+	if err != nil {
+		r.Logger.Error("pr_list_stale_reviews_failed", "err", err)
+		return nil, fmt.Errorf("list stale reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var res []repository.StaleReview
+
+	for rows.Next() {
+		var (
+			st       repository.StaleReview
+			inactive bool
+		)
+		if err := rows.Scan(&st.PRID, &st.ReviewerID, &st.AssignedAt, &inactive); err != nil {
+			r.Logger.Error("pr_list_stale_reviews_scan_failed", "err", err)
+			return nil, fmt.Errorf("scan stale reviews: %w", err)
+		}
+		st.Reason = repository.StaleReviewReasonAge
+		if inactive {
+			st.Reason = repository.StaleReviewReasonInactive
+		}
+		res = append(res, st)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_list_stale_reviews_rows_err", "err", err)
+		return nil, fmt.Errorf("iterate stale reviews: %w", err)
+	}
+
+	return res, nil
+}
+
 func (r *PRRepo) AddEvent(ctx context.Context, db repository.DBExecutor, event repository.PREvent) error {
 	const q = `
-INSERT INTO pr_events (pr_id, event_type, actor_user_id, old_user_id, new_user_id, created_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO pr_events (pr_id, event_type, actor_user_id, old_user_id, new_user_id, details, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
 `
 
 	createdAt := event.CreatedAt
@@ -273,9 +549,10 @@ VALUES ($1, $2, $3, $4, $5, $6);
 	_, err := db.Exec(ctx, q,
 		event.PRID,
 		string(event.EventType),
-		event.ActorUserID,
-		event.OldUserID,
-		event.NewUserID,
+		nullIfEmpty(event.ActorUserID),
+		nullIfEmpty(event.OldUserID),
+		nullIfEmpty(event.NewUserID),
+		event.Details,
 		createdAt,
 	)
 	if err != nil {
@@ -286,38 +563,133 @@ VALUES ($1, $2, $3, $4, $5, $6);
 	return nil
 }
 
-func (r *PRRepo) getReviewers(ctx context.Context, db repository.DBExecutor, prID string) ([]string, error) {
+func (r *PRRepo) loadLabels(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest) error {
+	const q = `
+SELECT COALESCE(array_agg(label ORDER BY label), '{}')
+FROM pr_labels
+WHERE pr_id = $1;
+`
+
+	if err := db.QueryRow(ctx, q, pr.ID).Scan(&pr.Labels); err != nil {
+		r.Logger.Error("pr_get_labels_failed", "pr_id", pr.ID, "err", err)
+		return fmt.Errorf("get labels for pr %q: %w", pr.ID, err)
+	}
+	return nil
+}
+
+func (r *PRRepo) loadReviewers(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest) error {
 	const q = `
-SELECT user_id
+SELECT user_id, COALESCE(fallback_team, ''), due_at
 FROM pr_reviewers
 WHERE pr_id = $1
+  AND released_at IS NULL
 ORDER BY slot;
 `
 
-	rows, err := db.Query(ctx, q, prID)
+	rows, err := db.Query(ctx, q, pr.ID)
 	if err != nil {
-		r.Logger.Error("pr_get_reviewers_failed", "pr_id", prID, "err", err)
-		return nil, fmt.Errorf("get reviewers for pr %q: %w", prID, err)
+		r.Logger.Error("pr_get_reviewers_failed", "pr_id", pr.ID, "err", err)
+		return fmt.Errorf("get reviewers for pr %q: %w", pr.ID, err)
 	}
 	defer rows.Close()
 
-	reviewers := make([]string, 0, 2)
+	reviewers := make([]string, 0, pr.MaxReviewers())
+	var fallback map[string]string
+	var dueAt map[string]time.Time
 
 	for rows.Next() {
-		var uid string
-		if err := rows.Scan(&uid); err != nil {
-			r.Logger.Error("pr_get_reviewers_scan_failed", "pr_id", prID, "err", err)
-			return nil, fmt.Errorf("scan reviewers for pr %q: %w", prID, err)
+		var (
+			uid, fallbackTeam string
+			due               *time.Time
+		)
+		if err := rows.Scan(&uid, &fallbackTeam, &due); err != nil {
+			r.Logger.Error("pr_get_reviewers_scan_failed", "pr_id", pr.ID, "err", err)
+			return fmt.Errorf("scan reviewers for pr %q: %w", pr.ID, err)
 		}
 		reviewers = append(reviewers, uid)
+		if fallbackTeam != "" {
+			if fallback == nil {
+				fallback = make(map[string]string)
+			}
+			fallback[uid] = fallbackTeam
+		}
+		if due != nil {
+			if dueAt == nil {
+				dueAt = make(map[string]time.Time)
+			}
+			dueAt[uid] = *due
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_get_reviewers_rows_err", "pr_id", pr.ID, "err", err)
+		return fmt.Errorf("iterate reviewers for pr %q: %w", pr.ID, err)
+	}
+
+	pr.AssignedReviewers = reviewers
+	pr.FallbackReviewers = fallback
+	pr.ReviewDueAt = dueAt
+	return nil
+}
+
+// loadReviews загружает последний вердикт каждого текущего ревьювера;
+// вердикты, отправленные до его назначения (например, до переназначения), не учитываются.
+func (r *PRRepo) loadReviews(ctx context.Context, db repository.DBExecutor, pr *domain.PullRequest) error {
+	const q = `
+SELECT DISTINCT ON (v.user_id) v.user_id, v.verdict, v.message, v.submitted_at
+FROM pr_reviews v
+JOIN pr_reviewers r ON r.pr_id = v.pr_id AND r.user_id = v.user_id AND r.released_at IS NULL
+WHERE v.pr_id = $1
+  AND v.submitted_at >= r.assigned_at
+ORDER BY v.user_id, v.id DESC;
+`
+
+	rows, err := db.Query(ctx, q, pr.ID)
+	if err != nil {
+		r.Logger.Error("pr_get_reviews_failed", "pr_id", pr.ID, "err", err)
+		return fmt.Errorf("get reviews for pr %q: %w", pr.ID, err)
+	}
+	defer rows.Close()
+
+	var reviews map[string]domain.Review
+	for rows.Next() {
+		var rv domain.Review
+		if err := rows.Scan(&rv.ReviewerID, &rv.Verdict, &rv.Message, &rv.SubmittedAt); err != nil {
+			r.Logger.Error("pr_get_reviews_scan_failed", "pr_id", pr.ID, "err", err)
+			return fmt.Errorf("scan reviews for pr %q: %w", pr.ID, err)
+		}
+		if reviews == nil {
+			reviews = make(map[string]domain.Review)
+		}
+		reviews[rv.ReviewerID] = rv
 	}
 
 	if err := rows.Err(); err != nil {
-		r.Logger.Error("pr_get_reviewers_rows_err", "pr_id", prID, "err", err)
-		return nil, fmt.Errorf("iterate reviewers for pr %q: %w", prID, err)
+		r.Logger.Error("pr_get_reviews_rows_err", "pr_id", pr.ID, "err", err)
+		return fmt.Errorf("iterate reviews for pr %q: %w", pr.ID, err)
 	}
 
-	return reviewers, nil
+	pr.Reviews = reviews
+	return nil
+}
+
+func (r *PRRepo) AddReview(ctx context.Context, db repository.DBExecutor, prID string, review domain.Review) error {
+	const q = `
+INSERT INTO pr_reviews (pr_id, user_id, verdict, message, submitted_at)
+VALUES ($1, $2, $3, $4, $5);
+`
+
+	_, err := db.Exec(ctx, q, prID, review.ReviewerID, string(review.Verdict), review.Message, review.SubmittedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "pr or user not found")
+		}
+		r.Logger.Error("pr_add_review_failed", "pr_id", prID, "user_id", review.ReviewerID, "err", err)
+		return fmt.Errorf("add review of %q to pr %q: %w", review.ReviewerID, prID, err)
+	}
+
+	return nil
 }
 
 func (r *PRRepo) GetAssignStats(
@@ -328,6 +700,7 @@ func (r *PRRepo) GetAssignStats(
 	q := `
 SELECT user_id, COUNT(*) AS cnt
 FROM pr_reviewers
+WHERE released_at IS NULL
 GROUP BY user_id;
 `
 
@@ -358,3 +731,194 @@ GROUP BY user_id;
 
 	return stats, nil
 }
+
+func (r *PRRepo) GetUserAssignStats(
+	ctx context.Context,
+	db repository.DBExecutor,
+	label string,
+) ([]repository.UserAssignStat, error) {
+
+	q := `
+SELECT u.user_id, u.team_name, u.is_active, u.review_weight, COUNT(r.user_id) AS cnt
+FROM users u
+LEFT JOIN pr_reviewers r ON r.user_id = u.user_id
+    AND r.released_at IS NULL
+    AND ($1 = '' OR EXISTS (SELECT 1 FROM pr_labels l WHERE l.pr_id = r.pr_id AND l.label = $1))
+GROUP BY u.user_id
+ORDER BY u.team_name, u.user_id;
+`
+
+	rows, err := db.Query(ctx, q, label)
+	if err != nil {
+		r.Logger.Error("pr_get_user_stats_failed", "err", err)
+		return nil, fmt.Errorf("get user assign stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []repository.UserAssignStat
+	for rows.Next() {
+		var st repository.UserAssignStat
+		if err := rows.Scan(&st.UserID, &st.TeamName, &st.IsActive, &st.Weight, &st.Count); err != nil {
+			r.Logger.Error("pr_scan_user_stats_failed", "err", err)
+			return nil, fmt.Errorf("scan user assign stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("rows error: %w", rows.Err())
+	}
+
+	return stats, nil
+}
+
+func (r *PRRepo) CountOpenReviews(
+	ctx context.Context,
+	db repository.DBExecutor,
+	userIDs []string,
+) (map[string]int, error) {
+	res := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	const q = `
+SELECT r.user_id, COUNT(*)
+FROM pr_reviewers r
+JOIN prs p ON p.pr_id = r.pr_id
+WHERE r.user_id = ANY($1)
+  AND r.released_at IS NULL
+  AND p.status = 'OPEN'
+GROUP BY r.user_id;
+`
+
+	rows, err := db.Query(ctx, q, userIDs)
+	if err != nil {
+		r.Logger.Error("pr_count_open_reviews_failed", "err", err)
+		return nil, fmt.Errorf("count open reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			uid   string
+			count int
+		)
+		if err := rows.Scan(&uid, &count); err != nil {
+			r.Logger.Error("pr_count_open_reviews_scan_failed", "err", err)
+			return nil, fmt.Errorf("scan open reviews: %w", err)
+		}
+		res[uid] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_count_open_reviews_rows_err", "err", err)
+		return nil, fmt.Errorf("iterate open reviews: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *PRRepo) GetLastAssignedAt(
+	ctx context.Context,
+	db repository.DBExecutor,
+	userIDs []string,
+) (map[string]time.Time, error) {
+	res := make(map[string]time.Time, len(userIDs))
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	const q = `
+SELECT user_id, MAX(assigned_at)
+FROM pr_reviewers
+WHERE user_id = ANY($1)
+  AND released_at IS NULL
+GROUP BY user_id;
+`
+
+	rows, err := db.Query(ctx, q, userIDs)
+	if err != nil {
+		r.Logger.Error("pr_get_last_assigned_failed", "err", err)
+		return nil, fmt.Errorf("get last assigned at: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			uid string
+			at  time.Time
+		)
+		if err := rows.Scan(&uid, &at); err != nil {
+			r.Logger.Error("pr_get_last_assigned_scan_failed", "err", err)
+			return nil, fmt.Errorf("scan last assigned at: %w", err)
+		}
+		res[uid] = at
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_get_last_assigned_rows_err", "err", err)
+		return nil, fmt.Errorf("iterate last assigned at: %w", err)
+	}
+
+	return res, nil
+}
+
+// GetPairAssignments возвращает, когда каждый из reviewerIDs назначался ревьювером
+// на PR автора authorID начиная с since.
+func (r *PRRepo) GetPairAssignments(
+	ctx context.Context,
+	db repository.DBExecutor,
+	authorID string,
+	reviewerIDs []string,
+	since time.Time,
+) (map[string][]time.Time, error) {
+	res := make(map[string][]time.Time, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return res, nil
+	}
+
+	const q = `
+SELECT r.user_id, r.assigned_at
+FROM pr_reviewers r
+JOIN prs p ON p.pr_id = r.pr_id
+WHERE p.author_id = $1
+  AND r.user_id = ANY($2)
+  AND r.assigned_at >= $3
+  AND r.released_at IS NULL
+ORDER BY r.assigned_at;
+`
+
+	rows, err := db.Query(ctx, q, authorID, reviewerIDs, since)
+	if err != nil {
+		r.Logger.Error("pr_get_pair_assignments_failed", "author_id", authorID, "err", err)
+		return nil, fmt.Errorf("get pair assignments for author %q: %w", authorID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			uid string
+			at  time.Time
+		)
+		if err := rows.Scan(&uid, &at); err != nil {
+			r.Logger.Error("pr_get_pair_assignments_scan_failed", "author_id", authorID, "err", err)
+			return nil, fmt.Errorf("scan pair assignment: %w", err)
+		}
+		res[uid] = append(res[uid], at)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("pr_get_pair_assignments_rows_err", "author_id", authorID, "err", err)
+		return nil, fmt.Errorf("iterate pair assignments: %w", err)
+	}
+
+	return res, nil
+}
+
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
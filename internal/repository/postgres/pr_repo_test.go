@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
 	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
 )
 
@@ -83,3 +86,424 @@ VALUES
 		t.Errorf("inactive user u4 should not be in stats, but present with %d", stats["u4"])
 	}
 }
+
+func TestPRRepo_AddRemoveReviewer_ReusesFreeSlot(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now()),
+       ('u3', 'Carol', 'backend', TRUE, now()), ('u4', 'Dave', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-1', 'u2', 0, now()), ('pr-1', 'u3', 1, now());
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	if err := repo.RemoveReviewer(ctx, testPool, "pr-1", "u2", time.Now()); err != nil {
+		t.Fatalf("RemoveReviewer() error = %v", err)
+	}
+	if err := repo.AddReviewer(ctx, testPool, "pr-1", "u4", "", nil, time.Now()); err != nil {
+		t.Fatalf("AddReviewer() error = %v", err)
+	}
+
+	_, reviewers, err := repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if len(reviewers) != 2 || reviewers[0] != "u4" || reviewers[1] != "u3" {
+		t.Fatalf("reviewers = %#v, want [u4 u3]", reviewers)
+	}
+
+	err = repo.RemoveReviewer(ctx, testPool, "pr-1", "u2", time.Now())
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotAssigned {
+		t.Fatalf("expected NOT_ASSIGNED, got %v", err)
+	}
+}
+
+func TestPRRepo_AddReview_LatestVerdictOfCurrentReviewers(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now()),
+       ('u3', 'Carol', 'backend', TRUE, now()), ('u4', 'Dave', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-1', 'u2', 0, now() - interval '1 hour'), ('pr-1', 'u3', 1, now() - interval '1 hour');
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	now := time.Now()
+	reviews := []domain.Review{
+		{ReviewerID: "u2", Verdict: domain.ReviewVerdictChangesRequested, Message: "fix tests", SubmittedAt: now.Add(-time.Minute)},
+		{ReviewerID: "u2", Verdict: domain.ReviewVerdictApproved, SubmittedAt: now},
+		{ReviewerID: "u3", Verdict: domain.ReviewVerdictCommented, SubmittedAt: now},
+	}
+	for _, rv := range reviews {
+		if err := repo.AddReview(ctx, testPool, "pr-1", rv); err != nil {
+			t.Fatalf("AddReview() error = %v", err)
+		}
+	}
+
+	// u3 заменён: его вердикт не относится к новому ревьюверу
+	if err := repo.ReplaceReviewer(ctx, testPool, "pr-1", "u3", "u4", "", nil, time.Now()); err != nil {
+		t.Fatalf("ReplaceReviewer() error = %v", err)
+	}
+
+	pr, _, err := repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if len(pr.Reviews) != 1 || pr.Reviews["u2"].Verdict != domain.ReviewVerdictApproved {
+		t.Fatalf("reviews = %#v, want only u2 APPROVED", pr.Reviews)
+	}
+}
+
+func TestPRRepo_SetStatus_CloseReleasesReviewers(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-1', 'u2', 0, now());
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	pr, _, err := repo.GetPRForUpdate(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRForUpdate() error = %v", err)
+	}
+	if err := pr.Close(time.Now()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := repo.SetStatus(ctx, testPool, pr); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+	if err := repo.ReleaseReviewers(ctx, testPool, "pr-1", time.Now()); err != nil {
+		t.Fatalf("ReleaseReviewers() error = %v", err)
+	}
+
+	got, reviewers, err := repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if got.Status != domain.PRStatusClosed || got.ClosedAt == nil || len(reviewers) != 0 {
+		t.Fatalf("expected CLOSED without reviewers, got %s %v %#v", got.Status, got.ClosedAt, reviewers)
+	}
+
+	// снятое назначение остаётся в истории, но не учитывается стратегиями
+	var released int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM pr_reviewers WHERE pr_id = 'pr-1' AND released_at IS NOT NULL`).Scan(&released); err != nil {
+		t.Fatalf("count released failed: %v", err)
+	}
+	if released != 1 {
+		t.Fatalf("released assignments = %d, want 1", released)
+	}
+	last, err := repo.GetLastAssignedAt(ctx, testPool, []string{"u2"})
+	if err != nil {
+		t.Fatalf("GetLastAssignedAt() error = %v", err)
+	}
+	if _, ok := last["u2"]; ok {
+		t.Fatalf("released assignment of u2 must not count, got %#v", last)
+	}
+}
+
+func TestPRRepo_ReplaceReviewer_KeepsHistory(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now()),
+       ('u3', 'Carol', 'backend', TRUE, now()), ('u4', 'Dave', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-1', 'u2', 0, now() - interval '1 hour'), ('pr-1', 'u3', 1, now() - interval '1 hour');
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	// момент назначения берётся из часов сервиса, как и submitted_at вердиктов
+	at := time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond)
+	if err := repo.ReplaceReviewer(ctx, testPool, "pr-1", "u2", "u4", "", nil, at); err != nil {
+		t.Fatalf("ReplaceReviewer() error = %v", err)
+	}
+	last, err := repo.GetLastAssignedAt(ctx, testPool, []string{"u4"})
+	if err != nil {
+		t.Fatalf("GetLastAssignedAt() error = %v", err)
+	}
+	if !last["u4"].Equal(at) {
+		t.Fatalf("assigned_at of u4 = %v, want %v", last["u4"], at)
+	}
+	// u2 снова назначается на тот же PR: в истории остаются оба назначения, учитывается текущее
+	if err := repo.ReplaceReviewer(ctx, testPool, "pr-1", "u3", "u2", "", nil, time.Now()); err != nil {
+		t.Fatalf("ReplaceReviewer() error = %v", err)
+	}
+
+	_, reviewers, err := repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if len(reviewers) != 2 || reviewers[0] != "u4" || reviewers[1] != "u2" {
+		t.Fatalf("reviewers = %#v, want [u4 u2]", reviewers)
+	}
+
+	open, err := repo.CountOpenReviews(ctx, testPool, []string{"u2", "u3", "u4"})
+	if err != nil {
+		t.Fatalf("CountOpenReviews() error = %v", err)
+	}
+	if open["u2"] != 1 || open["u3"] != 0 || open["u4"] != 1 {
+		t.Fatalf("open reviews = %#v, want u2=1 u3=0 u4=1", open)
+	}
+
+	pairs, err := repo.GetPairAssignments(ctx, testPool, "u1", []string{"u2", "u3", "u4"}, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetPairAssignments() error = %v", err)
+	}
+	if len(pairs["u2"]) != 1 || len(pairs["u3"]) != 0 || len(pairs["u4"]) != 1 {
+		t.Fatalf("pair assignments = %#v, want u2 and u4 once", pairs)
+	}
+
+	stats, err := repo.GetAssignStats(ctx, testPool)
+	if err != nil {
+		t.Fatalf("GetAssignStats() error = %v", err)
+	}
+	if stats["u2"] != 1 || stats["u3"] != 0 || stats["u4"] != 1 {
+		t.Fatalf("stats = %#v, want u2=1 u3=0 u4=1", stats)
+	}
+
+	var history int
+	if err := testPool.QueryRow(ctx, `SELECT COUNT(*) FROM pr_reviewers WHERE pr_id = 'pr-1'`).Scan(&history); err != nil {
+		t.Fatalf("count history failed: %v", err)
+	}
+	if history != 4 {
+		t.Fatalf("history rows = %d, want 4", history)
+	}
+
+	err = repo.ReplaceReviewer(ctx, testPool, "pr-1", "u3", "u1", "", nil, time.Now())
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotAssigned {
+		t.Fatalf("expected NOT_ASSIGNED for released reviewer, got %v", err)
+	}
+}
+
+func TestPRRepo_SetLabels_FiltersByLabel(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now()), ('pr-2', 'Second PR', 'u1', 'OPEN', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-1', 'u2', 0, now()), ('pr-2', 'u2', 0, now());
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	if err := repo.SetLabels(ctx, testPool, "pr-1", []string{"docs", "security"}); err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+	if err := repo.SetLabels(ctx, testPool, "pr-2", []string{"docs"}); err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+
+	pr, _, err := repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if len(pr.Labels) != 2 || pr.Labels[0] != "docs" || pr.Labels[1] != "security" {
+		t.Fatalf("labels = %#v, want [docs security]", pr.Labels)
+	}
+
+	prs, err := repo.ListPRsByReviewer(ctx, testPool, "u2", "security")
+	if err != nil {
+		t.Fatalf("ListPRsByReviewer() error = %v", err)
+	}
+	if len(prs) != 1 || prs[0].ID != "pr-1" {
+		t.Fatalf("filtered prs = %#v, want only pr-1", prs)
+	}
+
+	stats, err := repo.GetUserAssignStats(ctx, testPool, "security")
+	if err != nil {
+		t.Fatalf("GetUserAssignStats() error = %v", err)
+	}
+	for _, st := range stats {
+		if st.UserID == "u2" && st.Count != 1 {
+			t.Fatalf("u2 count with label filter = %d, want 1", st.Count)
+		}
+	}
+
+	if err := repo.SetLabels(ctx, testPool, "pr-1", nil); err != nil {
+		t.Fatalf("SetLabels(nil) error = %v", err)
+	}
+	pr, _, err = repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if len(pr.Labels) != 0 {
+		t.Fatalf("labels after clear = %#v, want none", pr.Labels)
+	}
+}
+
+func TestPRRepo_ListOverdueReviews(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now()),
+       ('u3', 'Carol', 'backend', TRUE, now()), ('u4', 'Dave', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	if err := repo.AddReviewer(ctx, testPool, "pr-1", "u2", "", &past, time.Now()); err != nil {
+		t.Fatalf("AddReviewer() error = %v", err)
+	}
+	if err := repo.AddReviewer(ctx, testPool, "pr-1", "u3", "", &past, time.Now()); err != nil {
+		t.Fatalf("AddReviewer() error = %v", err)
+	}
+	if err := repo.AddReviewer(ctx, testPool, "pr-1", "u4", "", &future, time.Now()); err != nil {
+		t.Fatalf("AddReviewer() error = %v", err)
+	}
+	if err := repo.AddReview(ctx, testPool, "pr-1", domain.Review{ReviewerID: "u3", Verdict: domain.ReviewVerdictApproved, SubmittedAt: time.Now()}); err != nil {
+		t.Fatalf("AddReview() error = %v", err)
+	}
+
+	pr, _, err := repo.GetPRByID(ctx, testPool, "pr-1")
+	if err != nil {
+		t.Fatalf("GetPRByID() error = %v", err)
+	}
+	if due, ok := pr.ReviewDueAt["u4"]; !ok || !due.Equal(future.Truncate(time.Microsecond)) {
+		t.Fatalf("due of u4 = %v, want %v", due, future)
+	}
+
+	overdue, err := repo.ListOverdueReviews(ctx, testPool, time.Now())
+	if err != nil {
+		t.Fatalf("ListOverdueReviews() error = %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].ReviewerID != "u2" || overdue[0].TeamName != "backend" {
+		t.Fatalf("overdue = %#v, want only u2", overdue)
+	}
+}
+
+func TestPRRepo_ListStaleReviews(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, stale_review_hours, created_at) VALUES ('backend', 24, now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now()),
+       ('u3', 'Carol', 'backend', FALSE, now()), ('u4', 'Dave', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, created_at)
+VALUES ('pr-1', 'First PR', 'u1', 'OPEN', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-1', 'u2', 0, now() - interval '2 days'), ('pr-1', 'u3', 1, now()), ('pr-1', 'u4', 2, now());
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	stale, err := repo.ListStaleReviews(ctx, testPool, time.Now())
+	if err != nil {
+		t.Fatalf("ListStaleReviews() error = %v", err)
+	}
+	if len(stale) != 2 ||
+		stale[0].ReviewerID != "u2" || stale[0].Reason != repository.StaleReviewReasonAge ||
+		stale[1].ReviewerID != "u3" || stale[1].Reason != repository.StaleReviewReasonInactive {
+		t.Fatalf("stale = %#v, want u2 (too old) and u3 (inactive)", stale)
+	}
+}
+
+func TestPRRepo_ListPRsByReviewer_OrdersByPriorityThenAge(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newPRRepo()
+
+	_, err := testPool.Exec(ctx, `
+INSERT INTO teams (team_name, created_at) VALUES ('backend', now());
+INSERT INTO users (user_id, username, team_name, is_active, created_at)
+VALUES ('u1', 'Alice', 'backend', TRUE, now()), ('u2', 'Bob', 'backend', TRUE, now());
+INSERT INTO prs (pr_id, pr_name, author_id, status, priority, created_at)
+VALUES ('pr-old', 'Old refactoring', 'u1', 'OPEN', 'normal', now() - interval '2 days'),
+       ('pr-new', 'New refactoring', 'u1', 'OPEN', 'normal', now()),
+       ('pr-low', 'Docs', 'u1', 'OPEN', 'low', now() - interval '3 days'),
+       ('pr-fix', 'Incident hotfix', 'u1', 'OPEN', 'normal', now());
+INSERT INTO pr_reviewers (pr_id, user_id, slot, assigned_at)
+VALUES ('pr-old', 'u2', 0, now()), ('pr-new', 'u2', 0, now()), ('pr-low', 'u2', 0, now()), ('pr-fix', 'u2', 0, now());
+UPDATE prs SET repository = 'org/api', url = 'https://git.example.com/org/api/pull/7', lines_added = 40, lines_deleted = 2
+WHERE pr_id = 'pr-fix';
+`)
+	if err != nil {
+		t.Fatalf("insert fixtures failed: %v", err)
+	}
+
+	if err := repo.SetPriority(ctx, testPool, "pr-fix", domain.PRPriorityHotfix); err != nil {
+		t.Fatalf("SetPriority() error = %v", err)
+	}
+
+	prs, err := repo.ListPRsByReviewer(ctx, testPool, "u2", "")
+	if err != nil {
+		t.Fatalf("ListPRsByReviewer() error = %v", err)
+	}
+	var got []string
+	for _, pr := range prs {
+		got = append(got, pr.ID)
+	}
+	want := []string{"pr-fix", "pr-old", "pr-new", "pr-low"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("queue = %v, want %v", got, want)
+	}
+	if prs[0].Priority != domain.PRPriorityHotfix {
+		t.Fatalf("priority = %q, want hotfix", prs[0].Priority)
+	}
+	if m := prs[0].Metadata; m.Repository != "org/api" || m.URL != "https://git.example.com/org/api/pull/7" || m.ChangedLines() != 42 {
+		t.Fatalf("metadata = %+v, want org/api with 42 changed lines", m)
+	}
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -23,10 +24,97 @@ func NewTeamRepo(logger log.Logger) repository.TeamRepository {
 	}
 }
 
+// teamSettingsColumns, teamSettingsArgs и teamSettingsDest должны перечислять поля в одном порядке.
+const teamSettingsColumns = `reviewers_count, fallback_teams, default_review_capacity, capacity_policy,
+    prefer_working_hours, working_hours_lookahead, pair_rotation_window_days,
+    min_senior_reviewers, max_junior_reviewers, seniority_fallback,
+    min_approvals, block_on_changes_requested, large_pr_lines, large_pr_extra_reviewers, label_rules,
+    review_sla_hours, stale_review_hours`
+
+const teamSettingsPlaceholders = `$2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18`
+
+type labelRuleJSON struct {
+	Label          string `json:"label"`
+	ReviewersCount int    `json:"reviewers_count,omitempty"`
+	RequireTeam    string `json:"require_team,omitempty"`
+}
+
+// labelRulesColumn хранит domain.TeamSettings.LabelRules в колонке label_rules (JSONB).
+type labelRulesColumn []domain.LabelRule
+
+func (c labelRulesColumn) MarshalJSON() ([]byte, error) {
+	out := make([]labelRuleJSON, 0, len(c))
+	for _, r := range c {
+		out = append(out, labelRuleJSON(r))
+	}
+	return json.Marshal(out)
+}
+
+func (c *labelRulesColumn) UnmarshalJSON(data []byte) error {
+	var in []labelRuleJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	rules := make([]domain.LabelRule, 0, len(in))
+	for _, r := range in {
+		rules = append(rules, domain.LabelRule(r))
+	}
+	*c = rules
+	return nil
+}
+
+func teamSettingsArgs(s domain.TeamSettings) []any {
+	s = s.WithDefaults()
+	return []any{
+		s.ReviewersCount,
+		nonNilStrings(s.FallbackTeams),
+		s.DefaultReviewCapacity,
+		string(s.CapacityPolicy),
+		s.PreferWorkingHours,
+		s.WorkingHoursLookahead,
+		s.PairRotationWindowDays,
+		s.MinSeniorReviewers,
+		s.MaxJuniorReviewers,
+		string(s.SeniorityFallback),
+		s.MinApprovals,
+		s.BlockOnChangesRequested,
+		s.LargePRLines,
+		s.LargePRExtraReviewers,
+		labelRulesColumn(s.LabelRules),
+		s.ReviewSLAHours,
+		s.StaleReviewHours,
+	}
+}
+
+func teamSettingsDest(s *domain.TeamSettings) []any {
+	return []any{
+		&s.ReviewersCount,
+		&s.FallbackTeams,
+		&s.DefaultReviewCapacity,
+		&s.CapacityPolicy,
+		&s.PreferWorkingHours,
+		&s.WorkingHoursLookahead,
+		&s.PairRotationWindowDays,
+		&s.MinSeniorReviewers,
+		&s.MaxJuniorReviewers,
+		&s.SeniorityFallback,
+		&s.MinApprovals,
+		&s.BlockOnChangesRequested,
+		&s.LargePRLines,
+		&s.LargePRExtraReviewers,
+		(*labelRulesColumn)(&s.LabelRules),
+		&s.ReviewSLAHours,
+		&s.StaleReviewHours,
+	}
+}
+
 func (r *TeamRepo) CreateTeam(ctx context.Context, db repository.DBExecutor, team *domain.Team) error {
-	const q = `INSERT INTO teams (team_name, created_at) VALUES ($1, now());`
+	q := `
+INSERT INTO teams (team_name, ` + teamSettingsColumns + `, created_at)
+VALUES ($1, ` + teamSettingsPlaceholders + `, now());
+`
 
-	_, err := db.Exec(ctx, q, team.Name)
+	_, err := db.Exec(ctx, q, append([]any{team.Name}, teamSettingsArgs(team.Settings)...)...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -46,15 +134,21 @@ func (r *TeamRepo) UpsertUsersForTeam(ctx context.Context, db repository.DBExecu
 	}
 
 	const q = `
-INSERT INTO users (user_id, username, team_name, is_active, created_at)
-VALUES ($1, $2, $3, $4, now())
+INSERT INTO users (user_id, username, team_name, is_active, skills, time_zone, work_start, work_end, seniority, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now())
 ON CONFLICT (user_id) DO UPDATE SET
-    username  = EXCLUDED.username,
-    team_name = EXCLUDED.team_name,
-    is_active = EXCLUDED.is_active;
+    username   = EXCLUDED.username,
+    team_name  = EXCLUDED.team_name,
+    is_active  = EXCLUDED.is_active,
+    skills     = EXCLUDED.skills,
+    time_zone  = EXCLUDED.time_zone,
+    work_start = EXCLUDED.work_start,
+    work_end   = EXCLUDED.work_end,
+    seniority  = EXCLUDED.seniority;
 `
 	for _, u := range members {
-		_, err := db.Exec(ctx, q, u.ID, u.Name, u.TeamName, u.IsActive)
+		start, end := workingHoursArgs(u.WorkingHours)
+		_, err := db.Exec(ctx, q, u.ID, u.Name, u.TeamName, u.IsActive, nonNilStrings(u.Skills), u.TimeZone, start, end, string(u.Seniority))
 		if err != nil {
 			r.Logger.Error("team_upsert_members_failed", "team", u.TeamName, "user_id", u.ID, "err", err)
 			return fmt.Errorf("upsert users for team %q: %w", u.TeamName, err)
@@ -65,10 +159,19 @@ ON CONFLICT (user_id) DO UPDATE SET
 }
 
 func (r *TeamRepo) GetTeamWithMembers(ctx context.Context, db repository.DBExecutor, teamName string) (*domain.Team, error) {
-	const qTeam = `SELECT team_name FROM teams WHERE team_name = $1;`
+	qTeam := `
+SELECT team_name, ` + teamSettingsColumns + `
+FROM teams
+WHERE team_name = $1;
+`
 
-	var foundTeam string
-	if err := db.QueryRow(ctx, qTeam, teamName).Scan(&foundTeam); err != nil {
+	var (
+		foundTeam string
+		settings  domain.TeamSettings
+	)
+	if err := db.QueryRow(ctx, qTeam, teamName).Scan(
+		append([]any{&foundTeam}, teamSettingsDest(&settings)...)...,
+	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
 		}
@@ -76,8 +179,8 @@ func (r *TeamRepo) GetTeamWithMembers(ctx context.Context, db repository.DBExecu
 		return nil, fmt.Errorf("get team %q: %w", teamName, err)
 	}
 
-	const qMembers = `
-SELECT user_id, username, team_name, is_active
+	qMembers := `
+SELECT ` + userColumns + `
 FROM users
 WHERE team_name = $1
 ORDER BY user_id;`
@@ -92,12 +195,12 @@ ORDER BY user_id;`
 	users := make([]domain.User, 0)
 
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			r.Logger.Error("team_get_members_scan_failed", "team", teamName, "err", err)
 			return nil, fmt.Errorf("scan team members for %q: %w", teamName, err)
 		}
-		users = append(users, u)
+		users = append(users, *u)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -110,6 +213,7 @@ ORDER BY user_id;`
 		r.Logger.Error("team_domain_build_failed", "team", teamName, "err", err)
 		return nil, fmt.Errorf("build domain team for %q: %w", teamName, err)
 	}
+	newTeam.Settings = settings
 
 	return newTeam, nil
 }
@@ -130,3 +234,30 @@ WHERE team_name = $1
 	affected := int(tag.RowsAffected())
 	return affected, nil
 }
+
+func (r *TeamRepo) UpdateTeamSettings(ctx context.Context, db repository.DBExecutor, teamName string, settings domain.TeamSettings) error {
+	q := `
+UPDATE teams
+SET (` + teamSettingsColumns + `) = (` + teamSettingsPlaceholders + `)
+WHERE team_name = $1;
+`
+	tag, err := db.Exec(ctx, q, append([]any{teamName}, teamSettingsArgs(settings)...)...)
+	if err != nil {
+		r.Logger.Error("team_update_settings_failed", "team", teamName, "err", err)
+		return fmt.Errorf("update settings for team %q: %w", teamName, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+
+	return nil
+}
+
+// nonNilStrings нужен, чтобы nil-слайс записывался как '{}', а не NULL.
+func nonNilStrings(v []string) []string {
+	if v == nil {
+		return []string{}
+	}
+	return v
+}
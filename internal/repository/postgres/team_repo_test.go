@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
@@ -54,6 +55,76 @@ func TestTeamRepo_CreateAndGetTeamWithMembers_Success(t *testing.T) {
 	if got.Members[0].ID != "u1" || got.Members[1].ID != "u2" {
 		t.Errorf("unexpected members: %+v", got.Members)
 	}
+
+	if got.Settings.ReviewersCount != domain.DefaultReviewersCount {
+		t.Errorf("reviewers count = %d, want %d", got.Settings.ReviewersCount, domain.DefaultReviewersCount)
+	}
+}
+
+func TestTeamRepo_UpdateTeamSettings(t *testing.T) {
+	truncateAll(t)
+
+	ctx := context.Background()
+	repo := newTeamRepo()
+
+	team, _ := domain.NewTeam("backend", nil)
+	if err := repo.CreateTeam(ctx, testPool, team); err != nil {
+		t.Fatalf("CreateTeam() error = %v", err)
+	}
+
+	settings := team.Settings
+	settings.ReviewersCount = 3
+	settings.PreferWorkingHours = true
+	settings.WorkingHoursLookahead = 4
+	settings.MinSeniorReviewers = 1
+	one := 1
+	settings.MaxJuniorReviewers = &one
+	settings.SeniorityFallback = domain.SeniorityFallbackIgnore
+	settings.MinApprovals = 2
+	settings.BlockOnChangesRequested = true
+	settings.LargePRLines = 500
+	settings.LargePRExtraReviewers = 2
+	settings.LabelRules = []domain.LabelRule{{Label: "security", RequireTeam: "security"}, {Label: "docs", ReviewersCount: 1}}
+	settings.ReviewSLAHours = 8
+	settings.StaleReviewHours = 48
+	if err := repo.UpdateTeamSettings(ctx, testPool, "backend", settings); err != nil {
+		t.Fatalf("UpdateTeamSettings() error = %v", err)
+	}
+
+	got, err := repo.GetTeamWithMembers(ctx, testPool, "backend")
+	if err != nil {
+		t.Fatalf("GetTeamWithMembers() error = %v", err)
+	}
+	if got.Settings.ReviewersCount != 3 {
+		t.Errorf("reviewers count = %d, want 3", got.Settings.ReviewersCount)
+	}
+	if !got.Settings.PreferWorkingHours || got.Settings.WorkingHoursLookahead != 4 {
+		t.Errorf("working hours policy = %v/%d, want true/4", got.Settings.PreferWorkingHours, got.Settings.WorkingHoursLookahead)
+	}
+	if got.Settings.MinSeniorReviewers != 1 || got.Settings.MaxJuniorReviewers == nil || *got.Settings.MaxJuniorReviewers != 1 ||
+		got.Settings.SeniorityFallback != domain.SeniorityFallbackIgnore {
+		t.Errorf("unexpected seniority policy: %+v", got.Settings)
+	}
+	if got.Settings.MinApprovals != 2 || !got.Settings.BlockOnChangesRequested {
+		t.Errorf("merge policy = %d/%v, want 2/true", got.Settings.MinApprovals, got.Settings.BlockOnChangesRequested)
+	}
+	if got.Settings.LargePRLines != 500 || got.Settings.LargePRExtraReviewers != 2 {
+		t.Errorf("large PR policy = %d/%d, want 500/2", got.Settings.LargePRLines, got.Settings.LargePRExtraReviewers)
+	}
+	if !reflect.DeepEqual(got.Settings.LabelRules, settings.LabelRules) {
+		t.Errorf("label rules = %+v, want %+v", got.Settings.LabelRules, settings.LabelRules)
+	}
+	if got.Settings.ReviewSLAHours != 8 {
+		t.Errorf("review SLA hours = %d, want 8", got.Settings.ReviewSLAHours)
+	}
+	if got.Settings.StaleReviewHours != 48 {
+		t.Errorf("stale review hours = %d, want 48", got.Settings.StaleReviewHours)
+	}
+
+	err = repo.UpdateTeamSettings(ctx, testPool, "missing", settings)
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotFound {
+		t.Errorf("expected NOT_FOUND for missing team, got %v", err)
+	}
 }
 
 func TestTeamRepo_CreateTeam_AlreadyExists(t *testing.T) {
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
 	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
@@ -22,21 +24,37 @@ func NewUserRepo(logger log.Logger) repository.UserRepository {
 	}
 }
 
+const userColumns = `user_id, username, team_name, is_active, review_capacity, skills, time_zone, work_start, work_end, seniority, review_weight`
+
+func scanUser(row pgx.Row) (*domain.User, error) {
+	var (
+		u          domain.User
+		start, end *int
+	)
+	if err := row.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive, &u.ReviewCapacity, &u.Skills, &u.TimeZone, &start, &end, &u.Seniority, &u.ReviewWeight); err != nil {
+		return nil, err
+	}
+	if start != nil && end != nil {
+		u.WorkingHours = &domain.WorkingHours{Start: *start, End: *end}
+	}
+	return &u, nil
+}
+
+func workingHoursArgs(wh *domain.WorkingHours) (start, end any) {
+	if wh == nil {
+		return nil, nil
+	}
+	return wh.Start, wh.End
+}
+
 func (r *UserRepo) GetUserByID(ctx context.Context, db repository.DBExecutor, userID string) (*domain.User, error) {
-	const q = `
-SELECT user_id, username, team_name, is_active
+	q := `
+SELECT ` + userColumns + `
 FROM users
 WHERE user_id = $1;
 `
 
-	var (
-		id       string
-		username string
-		teamName string
-		isActive bool
-	)
-
-	err := db.QueryRow(ctx, q, userID).Scan(&id, &username, &teamName, &isActive)
+	u, err := scanUser(db.QueryRow(ctx, q, userID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
@@ -45,32 +63,18 @@ WHERE user_id = $1;
 		return nil, fmt.Errorf("get user %q: %w", userID, err)
 	}
 
-	u := &domain.User{
-		ID:       id,
-		Name:     username,
-		TeamName: teamName,
-		IsActive: isActive,
-	}
-
 	return u, nil
 }
 
 func (r *UserRepo) SetUserIsActive(ctx context.Context, db repository.DBExecutor, userID string, isActive bool) (*domain.User, error) {
-	const q = `
+	q := `
 UPDATE users
 SET is_active = $1
 WHERE user_id = $2
-RETURNING user_id, username, team_name, is_active;
+RETURNING ` + userColumns + `;
 `
 
-	var (
-		id       string
-		username string
-		teamName string
-		active   bool
-	)
-
-	err := db.QueryRow(ctx, q, isActive, userID).Scan(&id, &username, &teamName, &active)
+	u, err := scanUser(db.QueryRow(ctx, q, isActive, userID))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
@@ -79,19 +83,113 @@ RETURNING user_id, username, team_name, is_active;
 		return nil, fmt.Errorf("set user is_active for %q: %w", userID, err)
 	}
 
-	u := &domain.User{
-		ID:       id,
-		Name:     username,
-		TeamName: teamName,
-		IsActive: active,
+	return u, nil
+}
+
+func (r *UserRepo) SetReviewCapacity(ctx context.Context, db repository.DBExecutor, userID string, capacity *int) (*domain.User, error) {
+	q := `
+UPDATE users
+SET review_capacity = $1
+WHERE user_id = $2
+RETURNING ` + userColumns + `;
+`
+
+	u, err := scanUser(db.QueryRow(ctx, q, capacity, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_set_review_capacity_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set review capacity for %q: %w", userID, err)
+	}
+
+	return u, nil
+}
+
+func (r *UserRepo) SetSkills(ctx context.Context, db repository.DBExecutor, userID string, skills []string) (*domain.User, error) {
+	q := `
+UPDATE users
+SET skills = $1
+WHERE user_id = $2
+RETURNING ` + userColumns + `;
+`
+
+	u, err := scanUser(db.QueryRow(ctx, q, nonNilStrings(skills), userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_set_skills_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set skills for %q: %w", userID, err)
+	}
+
+	return u, nil
+}
+
+func (r *UserRepo) SetSchedule(ctx context.Context, db repository.DBExecutor, userID, timeZone string, wh *domain.WorkingHours) (*domain.User, error) {
+	q := `
+UPDATE users
+SET time_zone = $1, work_start = $2, work_end = $3
+WHERE user_id = $4
+RETURNING ` + userColumns + `;
+`
+
+	start, end := workingHoursArgs(wh)
+	u, err := scanUser(db.QueryRow(ctx, q, timeZone, start, end, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_set_schedule_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set schedule for %q: %w", userID, err)
+	}
+
+	return u, nil
+}
+
+func (r *UserRepo) SetSeniority(ctx context.Context, db repository.DBExecutor, userID string, seniority domain.Seniority) (*domain.User, error) {
+	q := `
+UPDATE users
+SET seniority = $1
+WHERE user_id = $2
+RETURNING ` + userColumns + `;
+`
+
+	u, err := scanUser(db.QueryRow(ctx, q, string(seniority), userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_set_seniority_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set seniority for %q: %w", userID, err)
+	}
+
+	return u, nil
+}
+
+func (r *UserRepo) SetReviewWeight(ctx context.Context, db repository.DBExecutor, userID string, weight int) (*domain.User, error) {
+	q := `
+UPDATE users
+SET review_weight = $1
+WHERE user_id = $2
+RETURNING ` + userColumns + `;
+`
+
+	u, err := scanUser(db.QueryRow(ctx, q, weight, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_set_review_weight_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set review weight for %q: %w", userID, err)
 	}
 
 	return u, nil
 }
 
 func (r *UserRepo) ListUsersByTeam(ctx context.Context, db repository.DBExecutor, teamName string) ([]domain.User, error) {
-	const q = `
-SELECT user_id, username, team_name, is_active
+	q := `
+SELECT ` + userColumns + `
 FROM users
 WHERE team_name = $1
 ORDER BY user_id;
@@ -107,12 +205,12 @@ ORDER BY user_id;
 	users := make([]domain.User, 0)
 
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			r.Logger.Error("user_list_by_team_scan_failed", "team", teamName, "err", err)
 			return nil, fmt.Errorf("scan user by team %q: %w", teamName, err)
 		}
-		users = append(users, u)
+		users = append(users, *u)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -122,3 +220,216 @@ ORDER BY user_id;
 
 	return users, nil
 }
+
+// LockUsers берёт row-level блокировку на пользователей, чтобы параллельные
+// назначения видели согласованную нагрузку. Порядок по user_id исключает дедлоки.
+func (r *UserRepo) LockUsers(ctx context.Context, db repository.DBExecutor, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	const q = `
+SELECT user_id
+FROM users
+WHERE user_id = ANY($1)
+ORDER BY user_id
+FOR UPDATE;
+`
+
+	rows, err := db.Query(ctx, q, userIDs)
+	if err != nil {
+		r.Logger.Error("user_lock_failed", "err", err)
+		return fmt.Errorf("lock users: %w", err)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("user_lock_rows_err", "err", err)
+		return fmt.Errorf("lock users: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepo) AddUnavailability(ctx context.Context, db repository.DBExecutor, u *domain.Unavailability) error {
+	const q = `
+INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+`
+
+	err := db.QueryRow(ctx, q, u.UserID, u.StartsAt, u.EndsAt, u.Reason).Scan(&u.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_add_unavailability_failed", "user_id", u.UserID, "err", err)
+		return fmt.Errorf("add unavailability for %q: %w", u.UserID, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepo) ListUnavailability(ctx context.Context, db repository.DBExecutor, userID string, from time.Time) ([]domain.Unavailability, error) {
+	const q = `
+SELECT id, user_id, starts_at, ends_at, reason
+FROM user_unavailability
+WHERE user_id = $1 AND ends_at > $2
+ORDER BY starts_at, id;
+`
+
+	rows, err := db.Query(ctx, q, userID, from)
+	if err != nil {
+		r.Logger.Error("user_list_unavailability_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("list unavailability for %q: %w", userID, err)
+	}
+	defer rows.Close()
+
+	periods := make([]domain.Unavailability, 0)
+
+	for rows.Next() {
+		var u domain.Unavailability
+		if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason); err != nil {
+			r.Logger.Error("user_list_unavailability_scan_failed", "user_id", userID, "err", err)
+			return nil, fmt.Errorf("scan unavailability for %q: %w", userID, err)
+		}
+		periods = append(periods, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("user_list_unavailability_rows_err", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("iterate unavailability for %q: %w", userID, err)
+	}
+
+	return periods, nil
+}
+
+func (r *UserRepo) DeleteUnavailability(ctx context.Context, db repository.DBExecutor, userID string, id int64) error {
+	const q = `DELETE FROM user_unavailability WHERE id = $1 AND user_id = $2;`
+
+	tag, err := db.Exec(ctx, q, id, userID)
+	if err != nil {
+		r.Logger.Error("user_delete_unavailability_failed", "user_id", userID, "id", id, "err", err)
+		return fmt.Errorf("delete unavailability %d for %q: %w", id, userID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "unavailability not found")
+	}
+
+	return nil
+}
+
+func (r *UserRepo) UnavailableAt(ctx context.Context, db repository.DBExecutor, userIDs []string, at time.Time) (map[string]domain.Unavailability, error) {
+	res := make(map[string]domain.Unavailability)
+	if len(userIDs) == 0 {
+		return res, nil
+	}
+
+	const q = `
+SELECT DISTINCT ON (user_id) id, user_id, starts_at, ends_at, reason
+FROM user_unavailability
+WHERE user_id = ANY($1) AND starts_at <= $2 AND ends_at > $2
+ORDER BY user_id, ends_at DESC;
+`
+
+	rows, err := db.Query(ctx, q, userIDs, at)
+	if err != nil {
+		r.Logger.Error("user_unavailable_at_failed", "err", err)
+		return nil, fmt.Errorf("get unavailable users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.Unavailability
+		if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason); err != nil {
+			r.Logger.Error("user_unavailable_at_scan_failed", "err", err)
+			return nil, fmt.Errorf("scan unavailable user: %w", err)
+		}
+		res[u.UserID] = u
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("user_unavailable_at_rows_err", "err", err)
+		return nil, fmt.Errorf("iterate unavailable users: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *UserRepo) AddReviewerExclusion(ctx context.Context, db repository.DBExecutor, e *domain.ReviewerExclusion) error {
+	const q = `
+INSERT INTO reviewer_exclusions (user_id, other_user_id, reason, created_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (user_id, other_user_id) DO UPDATE SET reason = EXCLUDED.reason
+RETURNING created_at;
+`
+
+	err := db.QueryRow(ctx, q, e.UserID, e.OtherUserID, e.Reason).Scan(&e.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		r.Logger.Error("user_add_reviewer_exclusion_failed", "user_id", e.UserID, "other_user_id", e.OtherUserID, "err", err)
+		return fmt.Errorf("add reviewer exclusion %q/%q: %w", e.UserID, e.OtherUserID, err)
+	}
+
+	return nil
+}
+
+func (r *UserRepo) ListReviewerExclusions(ctx context.Context, db repository.DBExecutor, userID string) ([]domain.ReviewerExclusion, error) {
+	const q = `
+SELECT user_id, other_user_id, reason, created_at
+FROM reviewer_exclusions
+WHERE user_id = $1 OR other_user_id = $1
+ORDER BY user_id, other_user_id;
+`
+
+	rows, err := db.Query(ctx, q, userID)
+	if err != nil {
+		r.Logger.Error("user_list_reviewer_exclusions_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("list reviewer exclusions for %q: %w", userID, err)
+	}
+	defer rows.Close()
+
+	exclusions := make([]domain.ReviewerExclusion, 0)
+
+	for rows.Next() {
+		var e domain.ReviewerExclusion
+		if err := rows.Scan(&e.UserID, &e.OtherUserID, &e.Reason, &e.CreatedAt); err != nil {
+			r.Logger.Error("user_list_reviewer_exclusions_scan_failed", "user_id", userID, "err", err)
+			return nil, fmt.Errorf("scan reviewer exclusion for %q: %w", userID, err)
+		}
+		exclusions = append(exclusions, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.Logger.Error("user_list_reviewer_exclusions_rows_err", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("iterate reviewer exclusions for %q: %w", userID, err)
+	}
+
+	return exclusions, nil
+}
+
+// DeleteReviewerExclusion ожидает пару в любом порядке.
+func (r *UserRepo) DeleteReviewerExclusion(ctx context.Context, db repository.DBExecutor, userID, otherUserID string) error {
+	const q = `
+DELETE FROM reviewer_exclusions
+WHERE (user_id = $1 AND other_user_id = $2)
+   OR (user_id = $2 AND other_user_id = $1);
+`
+
+	tag, err := db.Exec(ctx, q, userID, otherUserID)
+	if err != nil {
+		r.Logger.Error("user_delete_reviewer_exclusion_failed", "user_id", userID, "other_user_id", otherUserID, "err", err)
+		return fmt.Errorf("delete reviewer exclusion %q/%q: %w", userID, otherUserID, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "reviewer exclusion not found")
+	}
+
+	return nil
+}
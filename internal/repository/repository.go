@@ -15,32 +15,96 @@ type DBExecutor interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
-
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, db DBExecutor, team *domain.Team) error
 	UpsertUsersForTeam(ctx context.Context, db DBExecutor, members []domain.User) error
 	GetTeamWithMembers(ctx context.Context, db DBExecutor, teamName string) (*domain.Team, error)
 	DeactivateUsersByTeam(ctx context.Context, db DBExecutor, teamName string) (int, error)
+	UpdateTeamSettings(ctx context.Context, db DBExecutor, teamName string, settings domain.TeamSettings) error
 }
 
-
 type UserRepository interface {
 	GetUserByID(ctx context.Context, db DBExecutor, userID string) (*domain.User, error)
 	SetUserIsActive(ctx context.Context, db DBExecutor, userID string, isActive bool) (*domain.User, error)
+	SetReviewCapacity(ctx context.Context, db DBExecutor, userID string, capacity *int) (*domain.User, error)
+	SetSkills(ctx context.Context, db DBExecutor, userID string, skills []string) (*domain.User, error)
+	SetSchedule(ctx context.Context, db DBExecutor, userID, timeZone string, wh *domain.WorkingHours) (*domain.User, error)
+	SetSeniority(ctx context.Context, db DBExecutor, userID string, seniority domain.Seniority) (*domain.User, error)
+	SetReviewWeight(ctx context.Context, db DBExecutor, userID string, weight int) (*domain.User, error)
 	ListUsersByTeam(ctx context.Context, db DBExecutor, teamName string) ([]domain.User, error)
-}
+	LockUsers(ctx context.Context, db DBExecutor, userIDs []string) error
 
+	AddUnavailability(ctx context.Context, db DBExecutor, u *domain.Unavailability) error
+	// ListUnavailability возвращает периоды пользователя, которые заканчиваются после from.
+	ListUnavailability(ctx context.Context, db DBExecutor, userID string, from time.Time) ([]domain.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, db DBExecutor, userID string, id int64) error
+	AddReviewerExclusion(ctx context.Context, db DBExecutor, e *domain.ReviewerExclusion) error
+	// ListReviewerExclusions возвращает все пары, в которые входит userID.
+	ListReviewerExclusions(ctx context.Context, db DBExecutor, userID string) ([]domain.ReviewerExclusion, error)
+	DeleteReviewerExclusion(ctx context.Context, db DBExecutor, userID, otherUserID string) error
+	// UnavailableAt возвращает пользователей из userIDs, отсутствующих в момент at, с периодом отсутствия.
+	UnavailableAt(ctx context.Context, db DBExecutor, userIDs []string, at time.Time) (map[string]domain.Unavailability, error)
+}
 
 type PRRepository interface {
 	CreatePR(ctx context.Context, db DBExecutor, pr *domain.PullRequest) error
 	GetAssignStats(ctx context.Context, db DBExecutor) (map[string]int, error)
+	// GetUserAssignStats возвращает всех пользователей с числом назначений, по команде и user_id;
+	// непустой label учитывает только назначения на PR с этой меткой.
+	GetUserAssignStats(ctx context.Context, db DBExecutor, label string) ([]UserAssignStat, error)
+	CountOpenReviews(ctx context.Context, db DBExecutor, userIDs []string) (map[string]int, error)
+	GetLastAssignedAt(ctx context.Context, db DBExecutor, userIDs []string) (map[string]time.Time, error)
+	// GetPairAssignments: id ревьювера -> моменты назначения на PR автора authorID начиная с since.
+	GetPairAssignments(ctx context.Context, db DBExecutor, authorID string, reviewerIDs []string, since time.Time) (map[string][]time.Time, error)
 	GetPRByID(ctx context.Context, db DBExecutor, prID string) (*domain.PullRequest, []string, error)
 	GetPRForUpdate(ctx context.Context, db DBExecutor, prID string) (*domain.PullRequest, []string, error)
 	SetMerged(ctx context.Context, db DBExecutor, pr *domain.PullRequest) error
-	ReplaceReviewer(ctx context.Context, db DBExecutor, prID string, oldID, newID string) error
-	AssignReviewers(ctx context.Context, db DBExecutor, prID string, reviewerIDs []string) error
-	ListPRsByReviewer(ctx context.Context, db DBExecutor, userID string) ([]domain.PullRequest, error)
+	// SetStatus сохраняет статус, closed_at и reviewers_required (пересчитывается при MarkReady).
+	SetStatus(ctx context.Context, db DBExecutor, pr *domain.PullRequest) error
+	// ReleaseReviewers, ReplaceReviewer и RemoveReviewer не удаляют назначения, а помечают их
+	// released_at. GetAssignStats, GetUserAssignStats, GetLastAssignedAt и GetPairAssignments
+	// учитывают только неснятые назначения.
+	// at — момент назначения или снятия по часам сервиса, как и submitted_at вердиктов (AddReview).
+	ReleaseReviewers(ctx context.Context, db DBExecutor, prID string, at time.Time) error
+	// ReplaceReviewer заменяет ревьювера; dueAt — срок ревью нового ревьювера (nil — без SLA).
+	ReplaceReviewer(ctx context.Context, db DBExecutor, prID string, oldID, newID string, fallbackTeam string, dueAt *time.Time, at time.Time) error
+	// AssignReviewers записывает ревьюверов PR со сроками из pr.ReviewDueAt.
+	AssignReviewers(ctx context.Context, db DBExecutor, pr *domain.PullRequest, at time.Time) error
+	// AddReviewer занимает первый свободный слот PR.
+	AddReviewer(ctx context.Context, db DBExecutor, prID, userID, fallbackTeam string, dueAt *time.Time, at time.Time) error
+	RemoveReviewer(ctx context.Context, db DBExecutor, prID, userID string, at time.Time) error
+	AddReview(ctx context.Context, db DBExecutor, prID string, review domain.Review) error
+	SetPriority(ctx context.Context, db DBExecutor, prID string, priority domain.PRPriority) error
+	// SetLabels заменяет метки PR.
+	SetLabels(ctx context.Context, db DBExecutor, prID string, labels []string) error
+	// ListPRsByReviewer возвращает очередь ревьювера: сначала более срочные PR, при равном
+	// приоритете — более старые; непустой label оставляет только PR с этой меткой.
+	ListPRsByReviewer(ctx context.Context, db DBExecutor, userID, label string) ([]domain.PullRequest, error)
+	// ListOverdueReviews возвращает назначения на открытые PR со сроком раньше now и без вердикта
+	// после назначения, по команде ревьювера, ревьюверу и сроку.
+	ListOverdueReviews(ctx context.Context, db DBExecutor, now time.Time) ([]OverdueReview, error)
+	// ListStaleReviews возвращает назначения на открытые PR без вердикта, которые пора
+	// переназначить: ревьювер неактивен или назначение старше stale_review_hours команды автора.
+	ListStaleReviews(ctx context.Context, db DBExecutor, now time.Time) ([]StaleReview, error)
 	AddEvent(ctx context.Context, db DBExecutor, event PREvent) error
+	AddAssignmentExplanation(ctx context.Context, db DBExecutor, e *domain.AssignmentExplanation) error
+	// ListAssignmentExplanations возвращает объяснения назначений PR в порядке создания.
+	ListAssignmentExplanations(ctx context.Context, db DBExecutor, prID string) ([]domain.AssignmentExplanation, error)
+}
+
+type CodeOwnerRepository interface {
+	AddRule(ctx context.Context, db DBExecutor, rule *domain.CodeOwnerRule) error
+	// ListRules возвращает правила в порядке применения: импортированные, затем ручные.
+	ListRules(ctx context.Context, db DBExecutor) ([]domain.CodeOwnerRule, error)
+	ReplaceRulesBySource(ctx context.Context, db DBExecutor, source domain.CodeOwnerSource, rules []domain.CodeOwnerRule) error
+}
+
+// LockRepository — межпроцессные блокировки на стороне БД.
+type LockRepository interface {
+	// TryLock берёт сессионную advisory-блокировку key на соединении db; false — она занята.
+	// Блокировка держится до Unlock или закрытия соединения.
+	TryLock(ctx context.Context, db DBExecutor, key int64) (bool, error)
+	Unlock(ctx context.Context, db DBExecutor, key int64) error
 }
 
 type PREventType string
@@ -50,6 +114,8 @@ const (
 	PREventTypeMerged           PREventType = "MERGED"
 	PREventTypeReviewerAssigned PREventType = "REVIEWER_ASSIGNED"
 	PREventTypeReviewerReplaced PREventType = "REVIEWER_REPLACED"
+	// PREventTypeForceMerged — merge администратором в обход политики команды.
+	PREventTypeForceMerged PREventType = "FORCE_MERGED"
 )
 
 type PREvent struct {
@@ -59,5 +125,43 @@ type PREvent struct {
 	ActorUserID string
 	OldUserID   string
 	NewUserID   string
-	CreatedAt   time.Time
+	// Details — произвольное пояснение (для FORCE_MERGED — обойдённые требования политики).
+	Details   string
+	CreatedAt time.Time
+}
+
+// OverdueReview — назначение ревьювера, срок ревью по которому прошёл.
+type OverdueReview struct {
+	PRID       string
+	PRName     string
+	AuthorID   string
+	ReviewerID string
+	TeamName   string
+	AssignedAt time.Time
+	DueAt      time.Time
+}
+
+// StaleReviewReason — почему назначение нужно переназначить.
+type StaleReviewReason string
+
+const (
+	StaleReviewReasonInactive StaleReviewReason = "reviewer_inactive"
+	StaleReviewReasonAge      StaleReviewReason = "too_old"
+)
+
+// StaleReview — назначение ревьювера, которое фоновый обработчик должен переназначить.
+type StaleReview struct {
+	PRID       string
+	ReviewerID string
+	AssignedAt time.Time
+	Reason     StaleReviewReason
+}
+
+// UserAssignStat — число назначений пользователя ревьювером за всё время.
+type UserAssignStat struct {
+	UserID   string
+	TeamName string
+	IsActive bool
+	Weight   int
+	Count    int
 }
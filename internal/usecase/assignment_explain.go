@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// newRand создаёт генератор для одного назначения: один и тот же seed даёт
+// одну и ту же последовательность, поэтому выбор можно повторить.
+func newRand(seed int64) Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// AssignmentExplain — сохранённое объяснение назначения и результат его повторного прогона.
+type AssignmentExplain struct {
+	domain.AssignmentExplanation
+	Replayed   []string
+	Reproduced bool
+}
+
+// ReplayAssignment заново прогоняет стратегию по сохранённым раундам с генератором rng,
+// созданным из того же seed, и возвращает выбранных ревьюверов в порядке выбора.
+func ReplayAssignment(e domain.AssignmentExplanation, rng Rand) ([]string, error) {
+	if e.Strategy == StrategyManual {
+		return nil, nil
+	}
+	selector, err := NewReviewerSelector(e.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	var picked []string
+	for _, round := range e.Rounds {
+		in := SelectionInput{
+			Candidates:     make([]domain.User, 0, len(round.Candidates)),
+			Assigned:       round.Assigned,
+			Count:          round.Count,
+			Load:           make(map[string]int, len(round.Candidates)),
+			LastAssignedAt: make(map[string]time.Time, len(round.Candidates)),
+			PairScore:      make(map[string]float64, len(round.Candidates)),
+			Rand:           rng,
+		}
+		for _, c := range round.Candidates {
+			in.Candidates = append(in.Candidates, domain.User{ID: c.UserID, IsActive: true, ReviewWeight: c.Weight})
+			in.Load[c.UserID] = c.OpenReviews
+			in.PairScore[c.UserID] = c.PairScore
+			if c.LastAssignedAt != nil {
+				in.LastAssignedAt[c.UserID] = *c.LastAssignedAt
+			}
+		}
+		picked = append(picked, selector.Select(in).ReviewerIDs...)
+	}
+	return picked, nil
+}
+
+// ExplainAssignment возвращает все назначения ревьюверов PR в порядке выполнения
+// и проверяет, что каждое из них воспроизводится.
+func (s *PRService) ExplainAssignment(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	prID string,
+) ([]AssignmentExplain, error) {
+	if _, _, err := s.prs.GetPRByID(ctx, exec, prID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.prs.ListAssignmentExplanations(ctx, exec, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]AssignmentExplain, 0, len(list))
+	for _, e := range list {
+		item := AssignmentExplain{AssignmentExplanation: e}
+		replayed, err := ReplayAssignment(e, s.rand(e.Seed))
+		if err != nil {
+			s.logger.Warn("assignment_replay_failed", "pr_id", prID, "assignment_id", e.ID, "err", err)
+		} else {
+			item.Replayed = replayed
+			item.Reproduced = slices.Equal(replayed, e.Picked())
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// explainAssignment собирает запись об одном выборе ревьюверов.
+func (s *PRService) explainAssignment(
+	kind domain.AssignmentKind,
+	req pickRequest,
+	res pickResult,
+	replaced string,
+) *domain.AssignmentExplanation {
+	e := &domain.AssignmentExplanation{
+		PRID:             req.PR.ID,
+		Kind:             kind,
+		Strategy:         res.Strategy,
+		Seed:             res.Seed,
+		Reviewers:        res.ReviewerIDs,
+		ReplacedReviewer: replaced,
+		Rounds:           res.Rounds,
+		Reason:           res.Reason,
+		CreatedAt:        s.now(),
+	}
+
+	seen := make(map[string]struct{})
+	exclude := func(id, reason string) {
+		if _, exists := seen[id]; exists || id == "" {
+			return
+		}
+		seen[id] = struct{}{}
+		e.Excluded = append(e.Excluded, domain.SkippedReviewer{UserID: id, Reason: reason})
+	}
+
+	exclude(req.Author.ID, "author")
+	exclude(replaced, "replaced reviewer")
+	for _, id := range req.Assigned {
+		if id != replaced {
+			exclude(id, "already assigned")
+		}
+	}
+	for _, id := range req.Exclude {
+		exclude(id, "excluded")
+	}
+	for _, sk := range res.Skipped {
+		exclude(sk.UserID, sk.Reason)
+	}
+	return e
+}
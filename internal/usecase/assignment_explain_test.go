@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+func TestExplainAssignment_ReproducesSelection(t *testing.T) {
+	newStore := func() *fakeStore {
+		store := newFakeStore()
+		store.addTeam("backend", domain.DefaultTeamSettings(),
+			domain.User{ID: "u1", IsActive: true},
+			domain.User{ID: "u2", IsActive: true},
+			domain.User{ID: "u3", IsActive: true},
+			domain.User{ID: "u4", IsActive: true},
+			domain.User{ID: "u5", IsActive: true},
+			domain.User{ID: "u6", IsActive: false},
+		)
+		return store
+	}
+	newService := func(store *fakeStore) *PRService {
+		svc := newTestPRService(store, StrategyRandom)
+		svc.rand = newRand
+		svc.seed = func() int64 { return 42 }
+		return svc
+	}
+
+	ctx := context.Background()
+	store := newStore()
+	svc := newService(store)
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	// тот же seed и те же данные дают тот же выбор
+	again, err := newService(newStore()).CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if !slices.Equal(pr.AssignedReviewers, again.AssignedReviewers) {
+		t.Fatalf("same seed gave %v and %v", pr.AssignedReviewers, again.AssignedReviewers)
+	}
+
+	if _, _, err := svc.ReassignReviewer(ctx, "pr-1", pr.AssignedReviewers[0]); err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+
+	explained, err := svc.ExplainAssignment(ctx, nil, "pr-1")
+	if err != nil {
+		t.Fatalf("ExplainAssignment() error = %v", err)
+	}
+	if len(explained) != 2 {
+		t.Fatalf("explanations len = %d, want 2", len(explained))
+	}
+
+	create, reassign := explained[0], explained[1]
+	if create.Kind != domain.AssignmentKindCreate || create.Strategy != StrategyRandom || create.Seed != 42 {
+		t.Fatalf("unexpected create explanation: %+v", create.AssignmentExplanation)
+	}
+	if !slices.Equal(create.Reviewers, pr.AssignedReviewers) {
+		t.Fatalf("explained reviewers = %v, want %v", create.Reviewers, pr.AssignedReviewers)
+	}
+	if len(create.Rounds) != 1 || len(create.Rounds[0].Candidates) != 4 {
+		t.Fatalf("expected one round over 4 candidates, got %+v", create.Rounds)
+	}
+	if !slices.Contains(create.Excluded, domain.SkippedReviewer{UserID: "u1", Reason: "author"}) ||
+		!slices.Contains(create.Excluded, domain.SkippedReviewer{UserID: "u6", Reason: "inactive"}) {
+		t.Fatalf("unexpected excluded: %+v", create.Excluded)
+	}
+
+	if reassign.Kind != domain.AssignmentKindReassign || reassign.ReplacedReviewer != pr.AssignedReviewers[0] {
+		t.Fatalf("unexpected reassign explanation: %+v", reassign.AssignmentExplanation)
+	}
+
+	for _, e := range explained {
+		if !e.Reproduced {
+			t.Fatalf("%s assignment not reproduced: picked %v, replayed %v", e.Kind, e.Picked(), e.Replayed)
+		}
+	}
+}
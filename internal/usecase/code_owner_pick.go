@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// codeOwnerPool — кандидаты-владельцы путей, затронутых одним правилом.
+type codeOwnerPool struct {
+	match domain.CodeOwnerMatch
+	// owners — все владельцы правила, включая неактивных и уже назначенных.
+	owners map[string]struct{}
+	pool   *candidatePool
+}
+
+// coveredBy сообщает, есть ли владелец правила среди оставшихся ревьюверов PR.
+func (p *codeOwnerPool) coveredBy(assigned, exclude, picked []string) bool {
+	excluded := make(map[string]struct{}, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = struct{}{}
+	}
+	for _, id := range assigned {
+		if _, gone := excluded[id]; gone {
+			continue
+		}
+		if _, ok := p.owners[id]; ok {
+			return true
+		}
+	}
+	for _, id := range picked {
+		if _, ok := p.owners[id]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// codeOwnerPools находит правила владения для изменённых файлов и собирает по каждому
+// пул кандидатов. Лимит открытых ревью берётся из настроек команды самого владельца.
+func (s *PRService) codeOwnerPools(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	req pickRequest,
+	taken map[string]struct{},
+	now time.Time,
+) ([]*codeOwnerPool, error) {
+	if len(req.ChangedFiles) == 0 || s.codeOwners == nil {
+		return nil, nil
+	}
+
+	rules, err := s.codeOwners.ListRules(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	matches := domain.MatchCodeOwners(rules, req.ChangedFiles)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	teams := map[string]*domain.Team{req.Home.Name: req.Home}
+	loadTeam := func(name string) (*domain.Team, error) {
+		if t, ok := teams[name]; ok {
+			return t, nil
+		}
+		t, err := s.teams.GetTeamWithMembers(ctx, exec, name)
+		if err != nil {
+			if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+				s.logger.Warn("code_owner_team_not_found", "team", name)
+				teams[name] = nil
+				return nil, nil
+			}
+			return nil, err
+		}
+		teams[name] = t
+		return t, nil
+	}
+
+	capacityOf := func(u domain.User) (int, bool) {
+		if t := teams[u.TeamName]; t != nil {
+			return t.Settings.ReviewCapacity(u)
+		}
+		return domain.DefaultTeamSettings().ReviewCapacity(u)
+	}
+
+	out := make([]*codeOwnerPool, 0, len(matches))
+	for _, m := range matches {
+		op := &codeOwnerPool{
+			match:  m,
+			owners: make(map[string]struct{}),
+			pool:   &candidatePool{team: req.Home, excluded: req.Conflicts},
+		}
+
+		var members []domain.User
+		for _, name := range m.Rule.Teams {
+			t, err := loadTeam(name)
+			if err != nil {
+				return nil, err
+			}
+			if t == nil {
+				continue
+			}
+			members = append(members, t.Members...)
+		}
+		for _, id := range m.Rule.Users {
+			u, err := s.users.GetUserByID(ctx, exec, id)
+			if err != nil {
+				if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+					s.logger.Warn("code_owner_user_not_found", "user_id", id)
+					continue
+				}
+				return nil, err
+			}
+			if _, err := loadTeam(u.TeamName); err != nil {
+				return nil, err
+			}
+			members = append(members, *u)
+		}
+
+		candidates := make([]domain.User, 0, len(members))
+		for _, u := range members {
+			if _, dup := op.owners[u.ID]; dup {
+				continue
+			}
+			op.owners[u.ID] = struct{}{}
+			candidates = append(candidates, u)
+		}
+
+		if err := s.prepareCandidatePool(ctx, exec, op.pool, notTaken(candidates, taken), capacityOf, now); err != nil {
+			return nil, err
+		}
+		out = append(out, op)
+	}
+
+	return out, nil
+}
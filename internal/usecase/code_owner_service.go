@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+type CodeOwnerService struct {
+	Rules  repository.CodeOwnerRepository
+	Teams  repository.TeamRepository
+	Users  repository.UserRepository
+	Tx     TxManager
+	Logger log.Logger
+}
+
+func NewCodeOwnerService(
+	rules repository.CodeOwnerRepository,
+	teams repository.TeamRepository,
+	users repository.UserRepository,
+	tx TxManager,
+	logger log.Logger,
+) *CodeOwnerService {
+	return &CodeOwnerService{
+		Rules:  rules,
+		Teams:  teams,
+		Users:  users,
+		Tx:     tx,
+		Logger: logger,
+	}
+}
+
+// CodeOwnersImport — результат импорта файла CODEOWNERS.
+type CodeOwnersImport struct {
+	Rules    []domain.CodeOwnerRule
+	Warnings []string
+}
+
+// AddRule добавляет правило владения; все команды и пользователи должны существовать.
+func (s *CodeOwnerService) AddRule(
+	ctx context.Context,
+	pattern string,
+	teams, users []string,
+) (*domain.CodeOwnerRule, error) {
+	rule, err := domain.NewCodeOwnerRule(pattern, teams, users, domain.CodeOwnerSourceManual)
+	if err != nil {
+		return nil, err
+	}
+	if !rule.HasOwners() {
+		return nil, domain.NewDomainError(domain.ErrorCodeInvalidArgument, "code owner rule must have at least one team or user")
+	}
+
+	err = s.Tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		for _, name := range rule.Teams {
+			if _, err := s.Teams.GetTeamWithMembers(ctx, exec, name); err != nil {
+				return ownerLookupError(err, "team", name)
+			}
+		}
+		for _, id := range rule.Users {
+			if _, err := s.Users.GetUserByID(ctx, exec, id); err != nil {
+				return ownerLookupError(err, "user", id)
+			}
+		}
+		return s.Rules.AddRule(ctx, exec, rule)
+	})
+	if err != nil {
+		s.Logger.Error("code_owner_add_usecase_failed", "pattern", pattern, "err", err)
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *CodeOwnerService) ListRules(
+	ctx context.Context,
+	exec repository.DBExecutor,
+) ([]domain.CodeOwnerRule, error) {
+	return s.Rules.ListRules(ctx, exec)
+}
+
+// ImportCodeOwners заменяет ранее импортированные правила содержимым файла CODEOWNERS.
+// Неизвестные команды и пользователи пропускаются с предупреждением; правило, у которого
+// не осталось ни одного известного владельца, не импортируется, чтобы не снять владение.
+func (s *CodeOwnerService) ImportCodeOwners(
+	ctx context.Context,
+	content string,
+) (*CodeOwnersImport, error) {
+	parsed, warnings := domain.ParseCodeOwners(content)
+	res := &CodeOwnersImport{Warnings: warnings}
+
+	err := s.Tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		known := make(map[string]bool)
+		exists := func(kind, name string) (bool, error) {
+			key := kind + ":" + name
+			if ok, cached := known[key]; cached {
+				return ok, nil
+			}
+			var err error
+			if kind == "team" {
+				_, err = s.Teams.GetTeamWithMembers(ctx, exec, name)
+			} else {
+				_, err = s.Users.GetUserByID(ctx, exec, name)
+			}
+			if err != nil {
+				if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+					known[key] = false
+					return false, nil
+				}
+				return false, err
+			}
+			known[key] = true
+			return true, nil
+		}
+
+		rules := make([]domain.CodeOwnerRule, 0, len(parsed))
+		for _, rule := range parsed {
+			hadOwners := rule.HasOwners()
+
+			teams := rule.Teams[:0]
+			for _, name := range rule.Teams {
+				ok, err := exists("team", name)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					res.Warnings = append(res.Warnings, fmt.Sprintf("%s: unknown team %q", rule.Pattern, name))
+					continue
+				}
+				teams = append(teams, name)
+			}
+			users := rule.Users[:0]
+			for _, id := range rule.Users {
+				ok, err := exists("user", id)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					res.Warnings = append(res.Warnings, fmt.Sprintf("%s: unknown user %q", rule.Pattern, id))
+					continue
+				}
+				users = append(users, id)
+			}
+			rule.Teams, rule.Users = teams, users
+
+			if hadOwners && !rule.HasOwners() {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("%s: skipped, no known owners", rule.Pattern))
+				continue
+			}
+			rules = append(rules, rule)
+		}
+
+		if err := s.Rules.ReplaceRulesBySource(ctx, exec, domain.CodeOwnerSourceCodeOwners, rules); err != nil {
+			return err
+		}
+		res.Rules = rules
+		return nil
+	})
+	if err != nil {
+		s.Logger.Error("code_owner_import_failed", "err", err)
+		return nil, err
+	}
+
+	s.Logger.Info("code_owners_imported", "rules", len(res.Rules), "warnings", len(res.Warnings))
+	return res, nil
+}
+
+func ownerLookupError(err error, kind, name string) error {
+	if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, fmt.Sprintf("code owner %s %q not found", kind, name))
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+)
+
+func TestImportCodeOwners_SkipsUnknownOwners(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(), domain.User{ID: "alice", IsActive: true})
+	store.codeOwners = []domain.CodeOwnerRule{
+		{Pattern: "/manual/", Teams: []string{"backend"}, Source: domain.CodeOwnerSourceManual},
+		{Pattern: "/old/", Teams: []string{"backend"}, Source: domain.CodeOwnerSourceCodeOwners},
+	}
+
+	svc := NewCodeOwnerService(
+		&fakeCodeOwnerRepo{store: store},
+		&fakeTeamRepo{store: store},
+		&fakeUserRepo{store: store},
+		fakeTx{},
+		log.FromContext(context.Background()),
+	)
+
+	res, err := svc.ImportCodeOwners(context.Background(), `
+*          @acme/backend @acme/ghosts
+/docs/     @alice @bob
+/vendor/   @acme/ghosts
+/gen/
+`)
+	if err != nil {
+		t.Fatalf("ImportCodeOwners() error = %v", err)
+	}
+
+	if len(res.Rules) != 3 {
+		t.Fatalf("imported %d rules, want 3: %+v", len(res.Rules), res.Rules)
+	}
+	if len(res.Rules[0].Teams) != 1 || res.Rules[0].Teams[0] != "backend" {
+		t.Fatalf("unknown team was not dropped: %+v", res.Rules[0])
+	}
+	if len(res.Rules[1].Users) != 1 || res.Rules[1].Users[0] != "alice" {
+		t.Fatalf("unknown user was not dropped: %+v", res.Rules[1])
+	}
+	if res.Rules[2].Pattern != "/gen/" {
+		t.Fatalf("ownerless rule must be kept, got %+v", res.Rules[2])
+	}
+	if len(res.Warnings) != 4 {
+		t.Fatalf("warnings = %#v, want 4", res.Warnings)
+	}
+
+	if len(store.codeOwners) != 4 || store.codeOwners[0].Pattern != "/manual/" {
+		t.Fatalf("manual rules must survive import: %+v", store.codeOwners)
+	}
+}
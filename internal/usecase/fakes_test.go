@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// In-memory реализации репозиториев для тестов usecase-слоя.
+// Нереализованные методы достаются от встроенного nil-интерфейса и паникуют при вызове.
+
+type fakeTx struct{}
+
+func (fakeTx) WithTx(ctx context.Context, fn func(ctx context.Context, exec repository.DBExecutor) error) error {
+	return fn(ctx, nil)
+}
+
+func (fakeTx) WithConn(ctx context.Context, fn func(ctx context.Context, exec repository.DBExecutor) error) error {
+	return fn(ctx, nil)
+}
+
+type fakeStore struct {
+	teams      map[string]*domain.Team
+	users      map[string]domain.User
+	prs        map[string]*domain.PullRequest
+	codeOwners []domain.CodeOwnerRule
+	away       []domain.Unavailability
+	explained  []domain.AssignmentExplanation
+	exclusions []domain.ReviewerExclusion
+	events     []repository.PREvent
+	// stale — назначения, которые ListStaleReviews считает зависшими (пока они на месте).
+	stale []repository.StaleReview
+	// locks — аргументы вызовов LockUsers по порядку.
+	locks [][]string
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		teams: make(map[string]*domain.Team),
+		users: make(map[string]domain.User),
+		prs:   make(map[string]*domain.PullRequest),
+	}
+}
+
+func (s *fakeStore) addTeam(name string, settings domain.TeamSettings, members ...domain.User) {
+	for i := range members {
+		members[i].TeamName = name
+		s.users[members[i].ID] = members[i]
+	}
+	s.teams[name] = &domain.Team{Name: name, Settings: settings}
+}
+
+type fakeTeamRepo struct {
+	repository.TeamRepository
+	store *fakeStore
+}
+
+func (r *fakeTeamRepo) GetTeamWithMembers(_ context.Context, _ repository.DBExecutor, teamName string) (*domain.Team, error) {
+	t, ok := r.store.teams[teamName]
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	members := make([]domain.User, 0)
+	for _, u := range r.store.users {
+		if u.TeamName == teamName {
+			members = append(members, u)
+		}
+	}
+	sortUsersByID(members)
+	return &domain.Team{Name: t.Name, Members: members, Settings: t.Settings}, nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	store *fakeStore
+}
+
+func (r *fakeUserRepo) GetUserByID(_ context.Context, _ repository.DBExecutor, userID string) (*domain.User, error) {
+	u, ok := r.store.users[userID]
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return &u, nil
+}
+
+func (r *fakeUserRepo) ListReviewerExclusions(_ context.Context, _ repository.DBExecutor, userID string) ([]domain.ReviewerExclusion, error) {
+	var res []domain.ReviewerExclusion
+	for _, e := range r.store.exclusions {
+		if e.UserID == userID || e.OtherUserID == userID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeUserRepo) LockUsers(_ context.Context, _ repository.DBExecutor, userIDs []string) error {
+	r.store.locks = append(r.store.locks, slices.Clone(userIDs))
+	return nil
+}
+
+func (r *fakeUserRepo) UnavailableAt(_ context.Context, _ repository.DBExecutor, userIDs []string, at time.Time) (map[string]domain.Unavailability, error) {
+	res := make(map[string]domain.Unavailability)
+	for _, id := range userIDs {
+		for _, u := range r.store.away {
+			if u.UserID == id && u.Covers(at) {
+				res[id] = u
+			}
+		}
+	}
+	return res, nil
+}
+
+type fakePRRepo struct {
+	repository.PRRepository
+	store *fakeStore
+}
+
+func (r *fakePRRepo) CreatePR(_ context.Context, _ repository.DBExecutor, pr *domain.PullRequest) error {
+	if _, exists := r.store.prs[pr.ID]; exists {
+		return domain.NewDomainError(domain.ErrorCodePRExists, "pr id already exists")
+	}
+	cp := *pr
+	cp.AssignedReviewers = nil
+	r.store.prs[pr.ID] = &cp
+	return nil
+}
+
+func (r *fakePRRepo) AssignReviewers(_ context.Context, _ repository.DBExecutor, pr *domain.PullRequest, _ time.Time) error {
+	stored := r.store.prs[pr.ID]
+	stored.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
+	stored.FallbackReviewers = copyStringMap(pr.FallbackReviewers)
+	stored.ReviewDueAt = maps.Clone(pr.ReviewDueAt)
+	return nil
+}
+
+func (r *fakePRRepo) GetPRForUpdate(_ context.Context, _ repository.DBExecutor, prID string) (*domain.PullRequest, []string, error) {
+	stored, ok := r.store.prs[prID]
+	if !ok {
+		return nil, nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
+	}
+	cp := *stored
+	cp.AssignedReviewers = append([]string(nil), stored.AssignedReviewers...)
+	cp.FallbackReviewers = copyStringMap(stored.FallbackReviewers)
+	cp.Reviews = maps.Clone(stored.Reviews)
+	cp.ReviewDueAt = maps.Clone(stored.ReviewDueAt)
+	return &cp, append([]string(nil), cp.AssignedReviewers...), nil
+}
+
+func (r *fakePRRepo) GetPRByID(ctx context.Context, exec repository.DBExecutor, prID string) (*domain.PullRequest, []string, error) {
+	return r.GetPRForUpdate(ctx, exec, prID)
+}
+
+func (r *fakePRRepo) AddAssignmentExplanation(_ context.Context, _ repository.DBExecutor, e *domain.AssignmentExplanation) error {
+	e.ID = int64(len(r.store.explained) + 1)
+	r.store.explained = append(r.store.explained, *e)
+	return nil
+}
+
+func (r *fakePRRepo) ListAssignmentExplanations(_ context.Context, _ repository.DBExecutor, prID string) ([]domain.AssignmentExplanation, error) {
+	var res []domain.AssignmentExplanation
+	for _, e := range r.store.explained {
+		if e.PRID == prID {
+			res = append(res, e)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) ReplaceReviewer(_ context.Context, _ repository.DBExecutor, prID, oldID, newID, fallbackTeam string, dueAt *time.Time, _ time.Time) error {
+	stored := r.store.prs[prID]
+	for i, id := range stored.AssignedReviewers {
+		if id == oldID {
+			stored.AssignedReviewers[i] = newID
+			delete(stored.FallbackReviewers, oldID)
+			delete(stored.Reviews, oldID)
+			delete(stored.ReviewDueAt, oldID)
+			stored.SetReviewDueAt(newID, dueAt)
+			if fallbackTeam != "" {
+				if stored.FallbackReviewers == nil {
+					stored.FallbackReviewers = make(map[string]string)
+				}
+				stored.FallbackReviewers[newID] = fallbackTeam
+			}
+			return nil
+		}
+	}
+	return domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+}
+
+func (r *fakePRRepo) AddReviewer(_ context.Context, _ repository.DBExecutor, prID, userID, _ string, dueAt *time.Time, _ time.Time) error {
+	stored := r.store.prs[prID]
+	stored.AssignedReviewers = append(stored.AssignedReviewers, userID)
+	stored.SetReviewDueAt(userID, dueAt)
+	return nil
+}
+
+func (r *fakePRRepo) ListOverdueReviews(_ context.Context, _ repository.DBExecutor, now time.Time) ([]repository.OverdueReview, error) {
+	var res []repository.OverdueReview
+	for _, pr := range r.store.prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			due, ok := pr.ReviewDueAt[id]
+			if _, reviewed := pr.Reviews[id]; !ok || reviewed || !due.Before(now) {
+				continue
+			}
+			res = append(res, repository.OverdueReview{
+				PRID:       pr.ID,
+				PRName:     pr.Name,
+				AuthorID:   pr.AuthorID,
+				ReviewerID: id,
+				TeamName:   r.store.users[id].TeamName,
+				DueAt:      due,
+			})
+		}
+	}
+	slices.SortFunc(res, func(a, b repository.OverdueReview) int {
+		return cmp.Or(
+			cmp.Compare(a.TeamName, b.TeamName),
+			cmp.Compare(a.ReviewerID, b.ReviewerID),
+			a.DueAt.Compare(b.DueAt),
+			cmp.Compare(a.PRID, b.PRID),
+		)
+	})
+	return res, nil
+}
+
+func (r *fakePRRepo) RemoveReviewer(_ context.Context, _ repository.DBExecutor, prID, userID string, _ time.Time) error {
+	stored := r.store.prs[prID]
+	for i, id := range stored.AssignedReviewers {
+		if id == userID {
+			stored.AssignedReviewers = append(stored.AssignedReviewers[:i:i], stored.AssignedReviewers[i+1:]...)
+			delete(stored.FallbackReviewers, userID)
+			delete(stored.Reviews, userID)
+			return nil
+		}
+	}
+	return domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this PR")
+}
+
+func (r *fakePRRepo) SetMerged(_ context.Context, _ repository.DBExecutor, pr *domain.PullRequest) error {
+	stored := r.store.prs[pr.ID]
+	stored.Status = pr.Status
+	stored.MergedAt = pr.MergedAt
+	return nil
+}
+
+func (r *fakePRRepo) SetStatus(_ context.Context, _ repository.DBExecutor, pr *domain.PullRequest) error {
+	stored := r.store.prs[pr.ID]
+	stored.Status = pr.Status
+	stored.ClosedAt = pr.ClosedAt
+	stored.ReviewersRequired = pr.ReviewersRequired
+	return nil
+}
+
+func (r *fakePRRepo) SetLabels(_ context.Context, _ repository.DBExecutor, prID string, labels []string) error {
+	stored, ok := r.store.prs[prID]
+	if !ok {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
+	}
+	stored.Labels = slices.Clone(labels)
+	return nil
+}
+
+func (r *fakePRRepo) SetPriority(_ context.Context, _ repository.DBExecutor, prID string, priority domain.PRPriority) error {
+	stored, ok := r.store.prs[prID]
+	if !ok {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "pr not found")
+	}
+	stored.Priority = priority
+	return nil
+}
+
+func (r *fakePRRepo) ReleaseReviewers(_ context.Context, _ repository.DBExecutor, prID string, _ time.Time) error {
+	stored := r.store.prs[prID]
+	stored.AssignedReviewers = nil
+	stored.FallbackReviewers = nil
+	stored.Reviews = nil
+	return nil
+}
+
+func (r *fakePRRepo) AddEvent(_ context.Context, _ repository.DBExecutor, event repository.PREvent) error {
+	r.store.events = append(r.store.events, event)
+	return nil
+}
+
+func (r *fakePRRepo) AddReview(_ context.Context, _ repository.DBExecutor, prID string, review domain.Review) error {
+	stored := r.store.prs[prID]
+	if stored.Reviews == nil {
+		stored.Reviews = make(map[string]domain.Review)
+	}
+	stored.Reviews[review.ReviewerID] = review
+	return nil
+}
+
+func (r *fakePRRepo) CountOpenReviews(_ context.Context, _ repository.DBExecutor, userIDs []string) (map[string]int, error) {
+	res := make(map[string]int)
+	for _, pr := range r.store.prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			res[id]++
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) GetLastAssignedAt(context.Context, repository.DBExecutor, []string) (map[string]time.Time, error) {
+	return map[string]time.Time{}, nil
+}
+
+// GetPairAssignments считает моментом назначения CreatedAt PR.
+func (r *fakePRRepo) GetPairAssignments(_ context.Context, _ repository.DBExecutor, authorID string, reviewerIDs []string, since time.Time) (map[string][]time.Time, error) {
+	res := make(map[string][]time.Time)
+	for _, pr := range r.store.prs {
+		if pr.AuthorID != authorID || pr.CreatedAt.Before(since) {
+			continue
+		}
+		for _, id := range pr.AssignedReviewers {
+			for _, want := range reviewerIDs {
+				if id == want {
+					res[id] = append(res[id], pr.CreatedAt)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func (r *fakePRRepo) ListStaleReviews(context.Context, repository.DBExecutor, time.Time) ([]repository.StaleReview, error) {
+	var res []repository.StaleReview
+	for _, st := range r.store.stale {
+		pr, ok := r.store.prs[st.PRID]
+		if ok && pr.Status == domain.PRStatusOpen && slices.Contains(pr.AssignedReviewers, st.ReviewerID) {
+			res = append(res, st)
+		}
+	}
+	return res, nil
+}
+
+// fakeLockRepo — блокировка, которую держит другая реплика, если busy.
+type fakeLockRepo struct {
+	busy bool
+	held bool
+}
+
+func (l *fakeLockRepo) TryLock(context.Context, repository.DBExecutor, int64) (bool, error) {
+	if l.busy || l.held {
+		return false, nil
+	}
+	l.held = true
+	return true, nil
+}
+
+func (l *fakeLockRepo) Unlock(context.Context, repository.DBExecutor, int64) error {
+	if !l.held {
+		return errors.New("lock is not held")
+	}
+	l.held = false
+	return nil
+}
+
+type fakeCodeOwnerRepo struct {
+	repository.CodeOwnerRepository
+	store *fakeStore
+}
+
+func (r *fakeCodeOwnerRepo) ListRules(context.Context, repository.DBExecutor) ([]domain.CodeOwnerRule, error) {
+	return append([]domain.CodeOwnerRule(nil), r.store.codeOwners...), nil
+}
+
+func (r *fakeCodeOwnerRepo) ReplaceRulesBySource(_ context.Context, _ repository.DBExecutor, source domain.CodeOwnerSource, rules []domain.CodeOwnerRule) error {
+	kept := r.store.codeOwners[:0]
+	for _, rule := range r.store.codeOwners {
+		if rule.Source != source {
+			kept = append(kept, rule)
+		}
+	}
+	for _, rule := range rules {
+		rule.Source = source
+		kept = append(kept, rule)
+	}
+	r.store.codeOwners = kept
+	return nil
+}
+
+func newTestPRService(store *fakeStore, strategy string) *PRService {
+	selectors, err := NewReviewerSelectors(strategy, nil)
+	if err != nil {
+		panic(err)
+	}
+	svc := NewPRService(
+		&fakePRRepo{store: store},
+		&fakeUserRepo{store: store},
+		&fakeTeamRepo{store: store},
+		&fakeCodeOwnerRepo{store: store},
+		fakeTx{},
+		selectors,
+		log.FromContext(context.Background()),
+	)
+	// Без генератора стратегия random берёт кандидатов по порядку, и тесты детерминированы.
+	svc.rand = func(int64) Rand { return nil }
+	return svc
+}
+
+func sortUsersByID(users []domain.User) {
+	for i := 1; i < len(users); i++ {
+		for j := i; j > 0 && users[j].ID < users[j-1].ID; j-- {
+			users[j], users[j-1] = users[j-1], users[j]
+		}
+	}
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// SetLabels заменяет метки PR. У открытого PR уже назначенные ревьюверы не меняются:
+// правила меток применяются при следующем выборе (markReady, reassign).
+func (s *PRService) SetLabels(
+	ctx context.Context,
+	prID string,
+	labels []string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		if err := pr.SetLabels(labels); err != nil {
+			return err
+		}
+		if err := s.prs.SetLabels(ctx, exec, pr.ID, pr.Labels); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_set_labels_usecase_failed", "pr_id", prID, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// labelTeamPool — кандидаты команды, ревьювер из которой нужен PR из-за метки.
+type labelTeamPool struct {
+	label domain.LabelTeam
+	pool  *candidatePool
+}
+
+// coveredBy сообщает, есть ли участник команды среди оставшихся ревьюверов PR.
+func (p *labelTeamPool) coveredBy(assigned, exclude, picked []string) bool {
+	for _, id := range assigned {
+		if !slices.Contains(exclude, id) && p.pool.team.HasMember(id) {
+			return true
+		}
+	}
+	for _, id := range picked {
+		if p.pool.team.HasMember(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// labelTeamPools собирает пулы кандидатов команд, которых требуют метки PR (req.LabelTeams).
+func (s *PRService) labelTeamPools(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	req pickRequest,
+	taken map[string]struct{},
+	now time.Time,
+) ([]*labelTeamPool, error) {
+	out := make([]*labelTeamPool, 0, len(req.LabelTeams))
+	for _, lt := range req.LabelTeams {
+		team, err := s.teams.GetTeamWithMembers(ctx, exec, lt.Team)
+		if err != nil {
+			if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+				s.logger.Warn("label_team_not_found", "team", req.Home.Name, "label", lt.Label, "required_team", lt.Team)
+				continue
+			}
+			return nil, err
+		}
+
+		pool, err := s.buildCandidatePool(ctx, exec, team, false, taken, req.Conflicts, now)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &labelTeamPool{label: lt, pool: pool})
+	}
+	return out, nil
+}
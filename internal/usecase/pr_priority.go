@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// SetPriority меняет приоритет PR. Уже назначенные ревьюверы не меняются:
+// правила hotfix применяются при следующем выборе (markReady, reassign).
+func (s *PRService) SetPriority(
+	ctx context.Context,
+	prID, priority string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		if err := pr.SetPriority(priority); err != nil {
+			return err
+		}
+		if err := s.prs.SetPriority(ctx, exec, pr.ID, pr.Priority); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_set_priority_usecase_failed", "pr_id", prID, "priority", priority, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
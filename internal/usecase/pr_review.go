@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// SubmitReview записывает вердикт назначенного ревьювера открытого PR.
+// Повторный вердикт того же ревьювера заменяет предыдущий.
+func (s *PRService) SubmitReview(
+	ctx context.Context,
+	prID, reviewerID, verdict, message string,
+) (*domain.PullRequest, error) {
+	v, err := domain.ParseReviewVerdict(verdict)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *domain.PullRequest
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+
+		review, err := pr.SubmitReview(reviewerID, v, message, s.now())
+		if err != nil {
+			return err
+		}
+
+		if err := s.prs.AddReview(ctx, exec, prID, *review); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_submit_review_usecase_failed", "pr_id", prID, "user_id", reviewerID, "err", err)
+		return nil, err
+	}
+
+	s.logger.Info("pr_review_submitted", "pr_id", prID, "user_id", reviewerID, "verdict", v)
+	return result, nil
+}
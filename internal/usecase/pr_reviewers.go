@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// AddReviewer вручную назначает ревьювера на открытый PR в свободный слот.
+func (s *PRService) AddReviewer(
+	ctx context.Context,
+	prID, userID string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+
+		conflicts, err := s.reviewerConflicts(ctx, exec, pr)
+		if err != nil {
+			return err
+		}
+		if err := s.checkRequestedReviewer(ctx, exec, userID, conflicts); err != nil {
+			return err
+		}
+		if err := pr.AddReviewer(userID); err != nil {
+			return err
+		}
+
+		author, err := s.users.GetUserByID(ctx, exec, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		authorTeam, err := s.authorTeam(ctx, exec, author, nil)
+		if err != nil {
+			return err
+		}
+		now := s.now()
+		dueAt, err := s.reviewDueAt(ctx, exec, authorTeam, userID, now)
+		if err != nil {
+			return err
+		}
+		pr.SetReviewDueAt(userID, dueAt)
+
+		if err := s.prs.AddReviewer(ctx, exec, prID, userID, "", dueAt, now); err != nil {
+			return err
+		}
+
+		pr.UncoveredSkills, err = s.uncoveredSkills(ctx, exec, pr.RequiredSkills, pr.AssignedReviewers, "")
+		if err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_add_reviewer_usecase_failed", "pr_id", prID, "user_id", userID, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveReviewer снимает ревьювера с открытого PR; освободившийся слот не заполняется.
+func (s *PRService) RemoveReviewer(
+	ctx context.Context,
+	prID, userID string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+
+		if err := pr.RemoveReviewer(userID); err != nil {
+			return err
+		}
+
+		if err := s.prs.RemoveReviewer(ctx, exec, prID, userID, s.now()); err != nil {
+			return err
+		}
+
+		pr.UncoveredSkills, err = s.uncoveredSkills(ctx, exec, pr.RequiredSkills, pr.AssignedReviewers, "")
+		if err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_remove_reviewer_usecase_failed", "pr_id", prID, "user_id", userID, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkRequestedReviewer проверяет, что вручную назначаемый ревьювер существует, активен
+// и не исключён для PR (conflicts, см. reviewerConflicts).
+func (s *PRService) checkRequestedReviewer(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	conflicts map[string]string,
+) error {
+	u, err := s.users.GetUserByID(ctx, exec, userID)
+	if err != nil {
+		return err
+	}
+	if !u.IsActive {
+		return domain.NewDomainError(domain.ErrorCodeInvalidArgument, "reviewer "+userID+" is inactive")
+	}
+	if reason, excluded := conflicts[userID]; excluded {
+		return domain.NewDomainError(domain.ErrorCodeInvalidArgument, "reviewer "+userID+": "+reason)
+	}
+	return nil
+}
+
+// pickRequested проверяет выбранную вручную замену теми же правилами, что и автоматический
+// выбор: активна, не автор, ещё не назначена, из команды req.Home или её fallback-команд,
+// не исключена для PR, не отсутствует, не превышает лимит открытых ревью (если CapacityPolicy
+// не разрешает назначать сверх него) и не нарушает политику уровней. Нарушение — NO_CANDIDATE с причиной;
+// нарушение политики уровней при SeniorityFallback reject — SENIORITY_POLICY_UNSATISFIED, при ignore допускается.
+func (s *PRService) pickRequested(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	req pickRequest,
+	userID string,
+) (pickResult, error) {
+	u, err := s.users.GetUserByID(ctx, exec, userID)
+	if err != nil {
+		return pickResult{}, err
+	}
+
+	reject := func(reason string) (pickResult, error) {
+		return pickResult{}, domain.NewDomainError(domain.ErrorCodeNoCandidate, "cannot assign "+userID+": "+reason)
+	}
+	switch {
+	case !u.IsActive:
+		return reject("inactive")
+	case u.ID == req.Author.ID:
+		return reject("author cannot be reviewer")
+	case slices.Contains(req.Assigned, u.ID) || slices.Contains(req.Exclude, u.ID):
+		return reject("already assigned")
+	case u.TeamName != req.Home.Name && !slices.Contains(req.Home.Settings.FallbackTeams, u.TeamName):
+		return reject("team " + u.TeamName + " is not eligible for " + req.Home.Name)
+	}
+	if reason, excluded := req.Conflicts[u.ID]; excluded {
+		return reject(reason)
+	}
+
+	// Отсутствие и лимит открытых ревью проверяются так же, как в пуле автоматического выбора.
+	team := req.Home
+	if u.TeamName != req.Home.Name {
+		team, err = s.teams.GetTeamWithMembers(ctx, exec, u.TeamName)
+		if err != nil {
+			return pickResult{}, err
+		}
+	}
+	now := s.now()
+	pool := &candidatePool{team: team}
+	if err := s.prepareCandidatePool(ctx, exec, pool, []domain.User{*u}, team.Settings.ReviewCapacity, now); err != nil {
+		return pickResult{}, err
+	}
+	if len(pool.candidates) == 0 {
+		return reject(pool.skipped[u.ID])
+	}
+	if err := s.lockCandidatePools(ctx, exec, []*candidatePool{pool}, req.ReadOnly, req.Home.Settings, now); err != nil {
+		return pickResult{}, err
+	}
+	reason := "requested replacement " + u.ID
+	if len(pool.atCapacity) > 0 {
+		if !assignsOverCapacity(req.Home.Settings.CapacityPolicy) {
+			return reject(pool.skipped[u.ID])
+		}
+		s.logger.Warn("reviewers_assigned_over_capacity", "pr_id", req.PR.ID, "team", req.Home.Name, "reviewers", []string{u.ID})
+		reason += ", over capacity"
+	}
+
+	policy, err := s.seniorityPolicyFor(ctx, exec, req)
+	if err != nil {
+		return pickResult{}, err
+	}
+	if violation := policy.admit(*u); violation != "" {
+		switch req.Home.Settings.SeniorityFallback {
+		case domain.SeniorityFallbackReject:
+			return pickResult{}, domain.NewDomainError(domain.ErrorCodeSeniorityUnsatisfied,
+				"seniority policy of team "+req.Home.Name+" cannot be satisfied: "+violation)
+		case domain.SeniorityFallbackLeaveEmpty:
+			return reject(violation)
+		}
+		s.logger.Warn("seniority_policy_relaxed", "pr_id", req.PR.ID, "team", req.Home.Name, "violation", violation, "reviewers", []string{u.ID})
+	}
+
+	res := pickResult{
+		ReviewerIDs:     []string{u.ID},
+		Fallback:        make(map[string]string),
+		Reason:          reason,
+		UncoveredSkills: withoutSkillsOf(domain.NormalizeSkills(req.RequiredSkills), *u),
+		Strategy:        StrategyManual,
+	}
+	if u.TeamName != req.Home.Name {
+		res.Fallback[u.ID] = u.TeamName
+	}
+	return res, nil
+}
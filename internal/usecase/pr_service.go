@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
 	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
@@ -14,81 +17,105 @@ type Rand interface {
 }
 
 type PRService struct {
-	prs    repository.PRRepository
-	users  repository.UserRepository
-	teams  repository.TeamRepository
-	tx     TxManager
-	rand   Rand
-	logger log.Logger
+	prs        repository.PRRepository
+	users      repository.UserRepository
+	teams      repository.TeamRepository
+	codeOwners repository.CodeOwnerRepository
+	tx         TxManager
+	selectors  *ReviewerSelectors
+	logger     log.Logger
+	now        func() time.Time
+	// seed выдаёт seed для назначения, rand создаёт по нему генератор.
+	seed func() int64
+	rand func(seed int64) Rand
 }
 
 func NewPRService(
 	prs repository.PRRepository,
 	users repository.UserRepository,
 	teams repository.TeamRepository,
+	codeOwners repository.CodeOwnerRepository,
 	tx TxManager,
-	rand Rand,
+	selectors *ReviewerSelectors,
 	logger log.Logger,
 ) *PRService {
 	return &PRService{
-		prs:    prs,
-		users:  users,
-		teams:  teams,
-		tx:     tx,
-		rand:   rand,
-		logger: logger,
+		prs:        prs,
+		users:      users,
+		teams:      teams,
+		codeOwners: codeOwners,
+		tx:         tx,
+		selectors:  selectors,
+		logger:     logger,
+		now:        time.Now,
+		seed:       rand.Int63,
+		rand:       newRand,
 	}
 }
 
+// CreatePRInput — параметры создания PR. Всё, кроме ID, Name и AuthorID, необязательно.
+type CreatePRInput struct {
+	ID             string
+	Name           string
+	AuthorID       string
+	RequiredSkills []string
+	ChangedFiles   []string
+	// Reviewers — ревьюверы, которых автор запросил явно; автоматически добираются
+	// только оставшиеся слоты.
+	Reviewers []string
+	// ExcludeReviewers — кого не назначать на этот PR; сохраняется и действует при переназначении.
+	ExcludeReviewers []string
+	Metadata         domain.PRMetadata
+	// Labels — метки PR; правила меток команды автора меняют назначение ревьюверов.
+	Labels []string
+	// Priority — low, normal (по умолчанию), high или hotfix.
+	Priority string
+	// Draft — создать PR в статусе DRAFT без ревьюверов (назначаются при MarkReady).
+	Draft bool
+	// Seed — seed выбора ревьюверов, полученный из SuggestReviewers: с ним при тех же
+	// данных назначаются те же ревьюверы, что в предпросмотре. nil — новый seed.
+	Seed *int64
+}
+
 func (s *PRService) CreatePRWithAutoAssign(
 	ctx context.Context,
-	prID, prName, authorID string,
+	in CreatePRInput,
 ) (*domain.PullRequest, error) {
 	var created *domain.PullRequest
+	prID := in.ID
 
 	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
-		author, err := s.users.GetUserByID(ctx, exec, authorID)
+		pr, req, picked, err := s.planCreate(ctx, exec, in, false)
 		if err != nil {
 			return err
 		}
+		if len(pr.UncoveredSkills) > 0 {
+			s.logger.Warn("pr_required_skills_uncovered", "pr_id", pr.ID, "skills", pr.UncoveredSkills)
+		}
 
-		team, err := s.teams.GetTeamWithMembers(ctx, exec, author.TeamName)
-		if err != nil {
+		if err := s.prs.CreatePR(ctx, exec, pr); err != nil {
 			return err
 		}
-
-		candidates := make([]domain.User, 0, len(team.Members))
-		for _, m := range team.Members {
-			if !m.IsActive {
-				continue
-			}
-			if m.ID == author.ID {
-				continue
+		if len(pr.Labels) > 0 {
+			if err := s.prs.SetLabels(ctx, exec, pr.ID, pr.Labels); err != nil {
+				return err
 			}
-			candidates = append(candidates, m)
 		}
 
-		reviewerIDs := chooseReviewers(candidates, s.rand)
-
-		pr, err := domain.NewPullRequest(prID, prName, authorID)
-		if err != nil {
-			return err
+		if pr.Status == domain.PRStatusDraft {
+			created = pr
+			return nil
 		}
 
-		if err := pr.AssignReviewers(reviewerIDs); err != nil {
+		if err := s.prs.AssignReviewers(ctx, exec, pr, s.now()); err != nil {
 			return err
 		}
 
-		if err := s.prs.CreatePR(ctx, exec, pr); err != nil {
+		explanation := s.explainAssignment(domain.AssignmentKindCreate, req, picked, "")
+		if err := s.prs.AddAssignmentExplanation(ctx, exec, explanation); err != nil {
 			return err
 		}
 
-		if len(reviewerIDs) > 0 {
-			if err := s.prs.AssignReviewers(ctx, exec, pr.ID, reviewerIDs); err != nil {
-				return err
-			}
-		}
-
 		created = pr
 		return nil
 	})
@@ -105,10 +132,155 @@ func (s *PRService) CreatePRWithAutoAssign(
 	return created, nil
 }
 
+// planCreate собирает PR и выбирает ревьюверов, ничего не записывая.
+// Используется и при создании PR, и для предпросмотра (SuggestReviewers, readOnly).
+func (s *PRService) planCreate(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	in CreatePRInput,
+	readOnly bool,
+) (*domain.PullRequest, pickRequest, pickResult, error) {
+	author, err := s.users.GetUserByID(ctx, exec, in.AuthorID)
+	if err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+
+	team, err := s.teams.GetTeamWithMembers(ctx, exec, author.TeamName)
+	if err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+
+	newPR := domain.NewPullRequest
+	if in.Draft {
+		if len(in.Reviewers) > 0 {
+			return nil, pickRequest{}, pickResult{}, domain.NewDomainError(domain.ErrorCodeInvalidArgument,
+				"draft PR cannot have reviewers")
+		}
+		newPR = domain.NewDraftPullRequest
+	}
+	pr, err := newPR(in.ID, in.Name, in.AuthorID)
+	if err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+
+	if err := pr.SetMetadata(in.Metadata); err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+	if err := pr.SetLabels(in.Labels); err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+	if err := pr.SetPriority(in.Priority); err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+	if err := pr.SetReviewersRequired(team.Settings.ReviewersFor(pr.Metadata, pr.Labels)); err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+	pr.SetRequiredSkills(in.RequiredSkills)
+	pr.SetChangedFiles(in.ChangedFiles)
+	pr.SetExcludedReviewers(in.ExcludeReviewers)
+
+	if pr.Status == domain.PRStatusDraft {
+		return pr, pickRequest{}, pickResult{}, nil
+	}
+
+	req, picked, err := s.planReviewers(ctx, exec, pr, author, team, in.Reviewers, planOptions{Seed: in.Seed, ReadOnly: readOnly})
+	if err != nil {
+		return nil, pickRequest{}, pickResult{}, err
+	}
+	return pr, req, picked, nil
+}
+
+// planOptions — параметры выбора ревьюверов в planReviewers (см. pickRequest).
+type planOptions struct {
+	Seed     *int64
+	ReadOnly bool
+}
+
+// planReviewers назначает в pr (без ревьюверов) запрошенных автором ревьюверов
+// и добирает остальных стратегией команды, ничего не записывая.
+func (s *PRService) planReviewers(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pr *domain.PullRequest,
+	author *domain.User,
+	team *domain.Team,
+	reviewers []string,
+	opts planOptions,
+) (pickRequest, pickResult, error) {
+	conflicts, err := s.reviewerConflicts(ctx, exec, pr)
+	if err != nil {
+		return pickRequest{}, pickResult{}, err
+	}
+
+	for _, id := range reviewers {
+		if err := s.checkRequestedReviewer(ctx, exec, id, conflicts); err != nil {
+			return pickRequest{}, pickResult{}, err
+		}
+		if err := pr.AddReviewer(id); err != nil {
+			return pickRequest{}, pickResult{}, err
+		}
+	}
+	requested := append([]string(nil), pr.AssignedReviewers...)
+
+	uncovered, err := s.uncoveredSkills(ctx, exec, pr.RequiredSkills, requested, "")
+	if err != nil {
+		return pickRequest{}, pickResult{}, err
+	}
+
+	req := pickRequest{
+		PR:             pr,
+		Author:         author,
+		Home:           team,
+		Assigned:       requested,
+		Exclude:        []string{author.ID},
+		Count:          pr.MaxReviewers() - len(requested),
+		RequiredSkills: uncovered,
+		ChangedFiles:   pr.ChangedFiles,
+		Conflicts:      conflicts,
+		LabelTeams:     team.Settings.LabelTeams(pr.Labels),
+		Seed:           opts.Seed,
+		ReadOnly:       opts.ReadOnly,
+	}
+	picked := pickResult{UncoveredSkills: uncovered}
+	if req.Count > 0 {
+		picked, err = s.pickReviewers(ctx, exec, req)
+		if err != nil {
+			return pickRequest{}, pickResult{}, err
+		}
+	}
+
+	if err := pr.AssignReviewers(append(requested, picked.ReviewerIDs...)); err != nil {
+		return pickRequest{}, pickResult{}, err
+	}
+	for id, fallbackTeam := range picked.Fallback {
+		if err := pr.MarkFallbackReviewer(id, fallbackTeam); err != nil {
+			return pickRequest{}, pickResult{}, err
+		}
+	}
+	if len(requested) > 0 {
+		reason := "requested by author: " + strings.Join(requested, ",")
+		if picked.Reason != "" {
+			reason += "; " + picked.Reason
+		}
+		picked.Reason = reason
+	}
+	pr.UncoveredSkills = picked.UncoveredSkills
+	pr.AssignmentReason = picked.Reason
+	pr.SkippedReviewers = picked.Skipped
+
+	if err := s.setReviewDeadlines(ctx, exec, pr, team); err != nil {
+		return pickRequest{}, pickResult{}, err
+	}
+
+	return req, picked, nil
+}
 
+// MergePR сливает PR, если он удовлетворяет политике merge команды автора (MERGE_BLOCKED иначе).
+// force (только для администратора) обходит политику; такой merge записывается в историю PR.
 func (s *PRService) MergePR(
 	ctx context.Context,
 	prID string,
+	force bool,
 ) (*domain.PullRequest, error) {
 	var result *domain.PullRequest
 
@@ -122,6 +294,17 @@ func (s *PRService) MergePR(
 			result = pr
 			return nil
 		}
+		if err := pr.EnsureOpen("merge"); err != nil {
+			return err
+		}
+
+		blockers, err := s.mergeBlockers(ctx, exec, pr)
+		if err != nil {
+			return err
+		}
+		if len(blockers) > 0 && !force {
+			return domain.NewDomainError(domain.ErrorCodeMergeBlocked, strings.Join(blockers, "; "))
+		}
 
 		pr.MarkMerged()
 
@@ -129,6 +312,22 @@ func (s *PRService) MergePR(
 			return err
 		}
 
+		if force {
+			details := "no policy requirements bypassed"
+			if len(blockers) > 0 {
+				details = "bypassed: " + strings.Join(blockers, "; ")
+			}
+			if err := s.prs.AddEvent(ctx, exec, repository.PREvent{
+				PRID:      pr.ID,
+				EventType: repository.PREventTypeForceMerged,
+				Details:   details,
+				CreatedAt: s.now(),
+			}); err != nil {
+				return err
+			}
+			s.logger.Warn("pr_force_merged", "pr_id", pr.ID, "details", details)
+		}
+
 		result = pr
 		return nil
 	})
@@ -140,9 +339,35 @@ func (s *PRService) MergePR(
 	return result, nil
 }
 
+// mergeBlockers проверяет PR по политике merge команды автора.
+func (s *PRService) mergeBlockers(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pr *domain.PullRequest,
+) ([]string, error) {
+	author, err := s.users.GetUserByID(ctx, exec, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	team, err := s.teams.GetTeamWithMembers(ctx, exec, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	return pr.MergeBlockers(team.Settings), nil
+}
+
 func (s *PRService) ReassignReviewer(
 	ctx context.Context,
 	prID, oldReviewerID string,
+) (*domain.PullRequest, string, error) {
+	return s.ReassignReviewerTo(ctx, prID, oldReviewerID, "")
+}
+
+// ReassignReviewerTo заменяет ревьювера на newReviewerID; пустой newReviewerID —
+// замена выбирается стратегией команды, как в ReassignReviewer.
+func (s *PRService) ReassignReviewerTo(
+	ctx context.Context,
+	prID, oldReviewerID, newReviewerID string,
 ) (*domain.PullRequest, string, error) {
 	var (
 		result *domain.PullRequest
@@ -155,8 +380,8 @@ func (s *PRService) ReassignReviewer(
 			return err
 		}
 
-		if !pr.CanModifyReviewers() {
-			return domain.NewDomainError(domain.ErrorCodePRMerged, "cannot reassign on merged PR")
+		if err := pr.EnsureOpen("reassign"); err != nil {
+			return err
 		}
 
 		found := false
@@ -180,39 +405,92 @@ func (s *PRService) ReassignReviewer(
 			return err
 		}
 
-		assignedSet := make(map[string]struct{}, len(reviewers))
-		for _, id := range reviewers {
-			assignedSet[id] = struct{}{}
+		author, err := s.users.GetUserByID(ctx, exec, pr.AuthorID)
+		if err != nil {
+			return err
 		}
 
-		candidates := make([]domain.User, 0, len(team.Members))
-		for _, m := range team.Members {
-			if !m.IsActive {
-				continue
-			}
-			if m.ID == oldReviewerID {
-				continue
-			}
-			if m.ID == pr.AuthorID {
-				continue
-			}
-			if _, exists := assignedSet[m.ID]; exists {
-				continue
-			}
-			candidates = append(candidates, m)
+		uncovered, err := s.uncoveredSkills(ctx, exec, pr.RequiredSkills, reviewers, oldReviewerID)
+		if err != nil {
+			return err
 		}
 
-		if len(candidates) == 0 {
-			return domain.NewDomainError(domain.ErrorCodeNoCandidate, "no active replacement candidate in team")
+		conflicts, err := s.reviewerConflicts(ctx, exec, pr)
+		if err != nil {
+			return err
 		}
 
-		newID = chooseOne(candidates, s.rand)
+		authorTeam, err := s.authorTeam(ctx, exec, author, team)
+		if err != nil {
+			return err
+		}
+
+		// Кандидаты — из команды заменяемого ревьювера и её fallback-команд (с лимитами её
+		// участников), а политика выбора — команды автора, как при создании PR.
+		home := *team
+		home.Settings = authorTeam.Settings
+		home.Settings.FallbackTeams = team.Settings.FallbackTeams
+		home.Settings.DefaultReviewCapacity = team.Settings.DefaultReviewCapacity
+
+		req := pickRequest{
+			PR:             pr,
+			Author:         author,
+			Home:           &home,
+			Assigned:       reviewers,
+			Exclude:        []string{oldReviewerID, pr.AuthorID},
+			Count:          1,
+			RequiredSkills: uncovered,
+			ChangedFiles:   pr.ChangedFiles,
+			Conflicts:      conflicts,
+			LabelTeams:     authorTeam.Settings.LabelTeams(pr.Labels),
+		}
+		var picked pickResult
+		if newReviewerID != "" {
+			picked, err = s.pickRequested(ctx, exec, req, newReviewerID)
+		} else {
+			picked, err = s.pickReviewers(ctx, exec, req)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(picked.ReviewerIDs) == 0 {
+			msg := "no active replacement candidate in team"
+			if len(picked.Skipped) > 0 {
+				msg += " (skipped: " + describeSkipped(picked.Skipped) + ")"
+			}
+			return domain.NewDomainError(domain.ErrorCodeNoCandidate, msg)
+		}
+		newID = picked.ReviewerIDs[0]
+		fallbackTeam := picked.Fallback[newID]
+
+		pr.UncoveredSkills = picked.UncoveredSkills
+		pr.AssignmentReason = picked.Reason
+		pr.SkippedReviewers = picked.Skipped
 
 		if err := pr.ReplaceReviewer(oldReviewerID, newID); err != nil {
 			return err
 		}
 
-		if err := s.prs.ReplaceReviewer(ctx, exec, prID, oldReviewerID, newID); err != nil {
+		if fallbackTeam != "" {
+			if err := pr.MarkFallbackReviewer(newID, fallbackTeam); err != nil {
+				return err
+			}
+		}
+
+		now := s.now()
+		dueAt, err := s.reviewDueAt(ctx, exec, authorTeam, newID, now)
+		if err != nil {
+			return err
+		}
+		pr.SetReviewDueAt(newID, dueAt)
+
+		if err := s.prs.ReplaceReviewer(ctx, exec, prID, oldReviewerID, newID, fallbackTeam, dueAt, now); err != nil {
+			return err
+		}
+
+		explanation := s.explainAssignment(domain.AssignmentKindReassign, req, picked, oldReviewerID)
+		if err := s.prs.AddAssignmentExplanation(ctx, exec, explanation); err != nil {
 			return err
 		}
 
@@ -220,42 +498,42 @@ func (s *PRService) ReassignReviewer(
 		return nil
 	})
 	if err != nil {
-		s.logger.Error("pr_reassign_usecase_failed", "pr_id", prID, "old_reviewer", oldReviewerID, "err", err)
+		s.logger.Error("pr_reassign_usecase_failed", "pr_id", prID, "old_reviewer", oldReviewerID, "new_reviewer", newReviewerID, "err", err)
 		return nil, "", err
 	}
 
 	return result, newID, nil
 }
 
-func chooseReviewers(candidates []domain.User, r Rand) []string {
-	n := len(candidates)
-	switch n {
-	case 0:
-		return nil
-	case 1:
-		return []string{candidates[0].ID}
-	default:
-		if r == nil {
-			return []string{candidates[0].ID, candidates[1].ID}
+// uncoveredSkills возвращает навыки из required, которых нет ни у одного из
+// ревьюверов, кроме leaving.
+func (s *PRService) uncoveredSkills(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	required, reviewers []string,
+	leaving string,
+) ([]string, error) {
+	uncovered := domain.NormalizeSkills(required)
+	for _, id := range reviewers {
+		if len(uncovered) == 0 {
+			break
 		}
-
-		i := r.Intn(n)
-		j := r.Intn(n - 1)
-		if j >= i {
-			j++
+		if id == leaving {
+			continue
 		}
-
-		return []string{candidates[i].ID, candidates[j].ID}
+		u, err := s.users.GetUserByID(ctx, exec, id)
+		if err != nil {
+			return nil, err
+		}
+		uncovered = withoutSkillsOf(uncovered, *u)
 	}
+	return uncovered, nil
 }
 
-func chooseOne(candidates []domain.User, r Rand) string {
-	if len(candidates) == 0 {
-		return ""
-	}
-	if r == nil {
-		return candidates[0].ID
+func describeSkipped(skipped []domain.SkippedReviewer) string {
+	parts := make([]string, 0, len(skipped))
+	for _, sk := range skipped {
+		parts = append(parts, sk.UserID+": "+sk.Reason)
 	}
-	idx := r.Intn(len(candidates))
-	return candidates[idx].ID
+	return strings.Join(parts, "; ")
 }
@@ -1,9 +1,14 @@
 package usecase
 
 import (
+	"context"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
 )
 
 type fakeRand struct {
@@ -27,7 +32,7 @@ func (f *fakeRand) Intn(n int) int {
 }
 
 func TestChooseReviewers_NoCandidates(t *testing.T) {
-	res := chooseReviewers(nil, nil)
+	res := chooseReviewers(nil, 2, nil)
 	if len(res) != 0 {
 		t.Fatalf("expected 0 reviewers, got %d", len(res))
 	}
@@ -38,7 +43,7 @@ func TestChooseReviewers_OneCandidate(t *testing.T) {
 		{ID: "u1"},
 	}
 
-	res := chooseReviewers(candidates, nil)
+	res := chooseReviewers(candidates, 2, nil)
 	if len(res) != 1 || res[0] != "u1" {
 		t.Fatalf("expected [u1], got %#v", res)
 	}
@@ -51,7 +56,7 @@ func TestChooseReviewers_TwoOrMore_NoRand(t *testing.T) {
 		{ID: "u3"},
 	}
 
-	res := chooseReviewers(candidates, nil)
+	res := chooseReviewers(candidates, 2, nil)
 	if len(res) != 2 {
 		t.Fatalf("expected 2 reviewers, got %d", len(res))
 	}
@@ -70,7 +75,7 @@ func TestChooseReviewers_WithRand_Distinct(t *testing.T) {
 
 	r := &fakeRand{seq: []int{0, 1}}
 
-	res := chooseReviewers(candidates, r)
+	res := chooseReviewers(candidates, 2, r)
 	if len(res) != 2 {
 		t.Fatalf("expected 2 reviewers, got %d", len(res))
 	}
@@ -104,3 +109,1333 @@ func TestChooseOne_WithRand(t *testing.T) {
 		t.Fatalf("expected u3, got %s", res)
 	}
 }
+
+func TestChooseReviewers_RespectsCount(t *testing.T) {
+	candidates := []domain.User{
+		{ID: "u1"},
+		{ID: "u2"},
+		{ID: "u3"},
+		{ID: "u4"},
+	}
+
+	r := &fakeRand{seq: []int{3, 0, 1}}
+
+	res := chooseReviewers(candidates, 3, r)
+	if len(res) != 3 {
+		t.Fatalf("expected 3 reviewers, got %d", len(res))
+	}
+	seen := map[string]bool{}
+	for _, id := range res {
+		if seen[id] {
+			t.Fatalf("duplicate reviewer %s in %#v", id, res)
+		}
+		seen[id] = true
+	}
+}
+
+func TestCreatePRWithAutoAssign_FillsFromFallbackTeams(t *testing.T) {
+	store := newFakeStore()
+
+	backend := domain.DefaultTeamSettings()
+	backend.ReviewersCount = 3
+	backend.FallbackTeams = []string{"platform", "frontend"}
+	store.addTeam("backend", backend,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: false},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: false},
+	)
+	store.addTeam("frontend", domain.DefaultTeamSettings(),
+		domain.User{ID: "f1", IsActive: true},
+		domain.User{ID: "f2", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	want := []string{"u2", "f1", "f2"}
+	if len(pr.AssignedReviewers) != len(want) {
+		t.Fatalf("reviewers = %#v, want %#v", pr.AssignedReviewers, want)
+	}
+	for i := range want {
+		if pr.AssignedReviewers[i] != want[i] {
+			t.Fatalf("reviewers = %#v, want %#v", pr.AssignedReviewers, want)
+		}
+	}
+
+	if _, ok := pr.FallbackReviewers["u2"]; ok {
+		t.Fatalf("home team reviewer must not be marked as fallback")
+	}
+	if pr.FallbackReviewers["f1"] != "frontend" || pr.FallbackReviewers["f2"] != "frontend" {
+		t.Fatalf("unexpected fallback reviewers: %#v", pr.FallbackReviewers)
+	}
+}
+
+func TestReassignReviewer_UsesFallbackWhenTeamExhausted(t *testing.T) {
+	store := newFakeStore()
+
+	backend := domain.DefaultTeamSettings()
+	backend.FallbackTeams = []string{"platform"}
+	store.addTeam("backend", backend,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: true},
+		domain.User{ID: "p2", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u2" || pr.AssignedReviewers[1] != "p1" {
+		t.Fatalf("unexpected reviewers %#v", pr.AssignedReviewers)
+	}
+
+	pr, newID, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "p2" || pr.FallbackReviewers["p2"] != "platform" {
+		t.Fatalf("expected fallback replacement p2, got %s (%#v)", newID, pr.FallbackReviewers)
+	}
+
+	_, _, err = svc.ReassignReviewer(ctx, "pr-1", "p1")
+	de, ok := domain.AsDomainError(err)
+	if !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE, got %v", err)
+	}
+}
+
+func TestCreatePRWithAutoAssign_CapacityPolicies(t *testing.T) {
+	one := 1
+
+	setup := func(policy domain.CapacityPolicy, extra ...domain.User) *PRService {
+		store := newFakeStore()
+
+		settings := domain.DefaultTeamSettings()
+		settings.ReviewersCount = 1
+		settings.DefaultReviewCapacity = &one
+		settings.CapacityPolicy = policy
+
+		members := append([]domain.User{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		}, extra...)
+		store.addTeam("backend", settings, members...)
+
+		store.prs["busy"] = &domain.PullRequest{
+			ID:                "busy",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2", "u3"},
+		}
+
+		return newTestPRService(store, StrategyRandom)
+	}
+	ctx := context.Background()
+
+	pr, err := setup(domain.CapacityPolicyNoCandidate, domain.User{ID: "u4", IsActive: true}).
+		CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u4" {
+		t.Fatalf("expected reviewer under capacity [u4], got %#v", pr.AssignedReviewers)
+	}
+
+	_, err = setup(domain.CapacityPolicyNoCandidate).CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE, got %v", err)
+	}
+
+	pr, err = setup(domain.CapacityPolicyLeaveEmpty).CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("expected empty slot, got %#v", pr.AssignedReviewers)
+	}
+
+	pr, err = setup(domain.CapacityPolicyAssignAnyway).CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("expected reviewer assigned over capacity, got %#v", pr.AssignedReviewers)
+	}
+}
+
+func TestCreatePRWithAutoAssign_PrefersSkillCoverage(t *testing.T) {
+	store := newFakeStore()
+
+	settings := domain.DefaultTeamSettings()
+	settings.ReviewersCount = 2
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true, Skills: []string{"go"}},
+		domain.User{ID: "u4", IsActive: true, Skills: []string{"go", "postgres"}},
+		domain.User{ID: "u5", IsActive: true, Skills: []string{"kafka"}},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{
+		ID:             "pr-1",
+		Name:           "Add feature",
+		AuthorID:       "u1",
+		RequiredSkills: []string{"Postgres", "go", "kafka", "rust"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	want := []string{"u4", "u5"}
+	if len(pr.AssignedReviewers) != len(want) {
+		t.Fatalf("reviewers = %#v, want %#v", pr.AssignedReviewers, want)
+	}
+	for i := range want {
+		if pr.AssignedReviewers[i] != want[i] {
+			t.Fatalf("reviewers = %#v, want %#v", pr.AssignedReviewers, want)
+		}
+	}
+	if len(pr.UncoveredSkills) != 1 || pr.UncoveredSkills[0] != "rust" {
+		t.Fatalf("uncovered skills = %#v, want [rust]", pr.UncoveredSkills)
+	}
+
+	_, newID, err := svc.ReassignReviewer(context.Background(), "pr-1", "u4")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "u3" {
+		t.Fatalf("replacement = %q, want u3 (covers go)", newID)
+	}
+}
+
+func TestCreatePRWithAutoAssign_PicksCodeOwnersFirst(t *testing.T) {
+	store := newFakeStore()
+
+	settings := domain.DefaultTeamSettings()
+	settings.ReviewersCount = 2
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: false},
+		domain.User{ID: "p2", IsActive: true},
+		domain.User{ID: "p3", IsActive: true},
+	)
+	store.codeOwners = []domain.CodeOwnerRule{
+		{Pattern: "*", Teams: []string{"backend"}},
+		{Pattern: "/lib/", Teams: []string{"platform"}},
+		{Pattern: "/lib/http/", Users: []string{"u4"}},
+	}
+
+	svc := newTestPRService(store, StrategyRandom)
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{
+		ID:           "pr-1",
+		Name:         "Bump shared lib",
+		AuthorID:     "u1",
+		ChangedFiles: []string{"./lib/retry/retry.go", "lib/http/client.go"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	want := []string{"p2", "u4"}
+	if len(pr.AssignedReviewers) != len(want) {
+		t.Fatalf("reviewers = %#v, want %#v", pr.AssignedReviewers, want)
+	}
+	for i := range want {
+		if pr.AssignedReviewers[i] != want[i] {
+			t.Fatalf("reviewers = %#v, want %#v", pr.AssignedReviewers, want)
+		}
+	}
+	if pr.ChangedFiles[0] != "lib/retry/retry.go" {
+		t.Fatalf("changed files are not normalized: %#v", pr.ChangedFiles)
+	}
+
+	// замена владельца /lib/ берётся из других владельцев
+	_, newID, err := svc.ReassignReviewer(context.Background(), "pr-1", "p2")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "p3" {
+		t.Fatalf("replacement = %q, want p3", newID)
+	}
+
+	// других владельцев /lib/http/ нет — замена из команды заменяемого ревьювера
+	_, newID, err = svc.ReassignReviewer(context.Background(), "pr-1", "u4")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "u2" {
+		t.Fatalf("replacement = %q, want u2", newID)
+	}
+}
+
+func TestCreatePRWithAutoAssign_SkipsUnavailableReviewers(t *testing.T) {
+	now := time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+	store.away = []domain.Unavailability{
+		{UserID: "u2", StartsAt: now.Add(-24 * time.Hour), EndsAt: now.Add(24 * time.Hour), Reason: "vacation"},
+		// отпуск ещё не начался
+		{UserID: "u3", StartsAt: now.Add(time.Hour), EndsAt: now.Add(48 * time.Hour)},
+	}
+
+	svc := newTestPRService(store, StrategyRandom)
+	svc.now = func() time.Time { return now }
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u3" || pr.AssignedReviewers[1] != "u4" {
+		t.Fatalf("reviewers = %#v, want [u3 u4]", pr.AssignedReviewers)
+	}
+	if !store.users["u2"].IsActive {
+		t.Fatalf("is_active must not be touched")
+	}
+
+	// u3 уходит в отпуск — замены нет: u2 ещё отсутствует, u4 уже назначен
+	svc.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, _, err = svc.ReassignReviewer(context.Background(), "pr-1", "u3")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE, got %v", err)
+	}
+}
+
+func TestCreatePRWithAutoAssign_PrefersWorkingHours(t *testing.T) {
+	// 10:00 UTC: 19:00 в Токио, 13:00 в Москве
+	now := time.Date(2025, 7, 10, 10, 0, 0, 0, time.UTC)
+	office, err := domain.ParseWorkingHours("09:00", "18:00")
+	if err != nil {
+		t.Fatalf("ParseWorkingHours() error = %v", err)
+	}
+
+	setup := func(count, lookahead int) (*PRService, *fakeStore) {
+		store := newFakeStore()
+		settings := domain.DefaultTeamSettings()
+		settings.ReviewersCount = count
+		settings.PreferWorkingHours = true
+		settings.WorkingHoursLookahead = lookahead
+		store.addTeam("backend", settings,
+			domain.User{ID: "u1", IsActive: true},
+			domain.User{ID: "u2", IsActive: true, TimeZone: "Asia/Tokyo", WorkingHours: office},
+			domain.User{ID: "u3", IsActive: true, TimeZone: "Europe/Moscow", WorkingHours: office},
+			domain.User{ID: "u4", IsActive: false},
+		)
+		svc := newTestPRService(store, StrategyRandom)
+		svc.now = func() time.Time { return now }
+		return svc, store
+	}
+
+	svc, _ := setup(1, 0)
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Fatalf("reviewers = %#v, want [u3]", pr.AssignedReviewers)
+	}
+	skipped := make(map[string]string)
+	for _, sk := range pr.SkippedReviewers {
+		skipped[sk.UserID] = sk.Reason
+	}
+	if !strings.Contains(skipped["u2"], "outside working hours") || skipped["u4"] != "inactive" {
+		t.Fatalf("unexpected skip reasons: %#v", pr.SkippedReviewers)
+	}
+
+	// не хватает кандидатов в рабочее время — добираем тех, кто вне его
+	svc, _ = setup(2, 0)
+	pr, err = svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u3" || pr.AssignedReviewers[1] != "u2" {
+		t.Fatalf("reviewers = %#v, want [u3 u2]", pr.AssignedReviewers)
+	}
+	if !strings.Contains(pr.AssignmentReason, "outside working hours") {
+		t.Fatalf("reason = %q", pr.AssignmentReason)
+	}
+
+	// до начала рабочего дня в Токио 14 часов — с таким запасом u2 подходит
+	svc, _ = setup(2, 14)
+	pr, err = svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	for _, sk := range pr.SkippedReviewers {
+		if sk.UserID == "u2" {
+			t.Fatalf("u2 must not be skipped with lookahead: %#v", pr.SkippedReviewers)
+		}
+	}
+}
+
+func TestCreatePRWithAutoAssign_PairRotationAvoidsRecentPairs(t *testing.T) {
+	now := time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+
+	store := newFakeStore()
+	settings := domain.DefaultTeamSettings()
+	settings.PairRotationWindowDays = 10
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		domain.User{ID: "u5", IsActive: true},
+	)
+	history := []*domain.PullRequest{
+		{ID: "old-1", AuthorID: "u1", AssignedReviewers: []string{"u2"}, CreatedAt: now.Add(-24 * time.Hour)},
+		{ID: "old-2", AuthorID: "u1", AssignedReviewers: []string{"u3"}, CreatedAt: now.Add(-5 * 24 * time.Hour)},
+		// за пределами окна
+		{ID: "old-3", AuthorID: "u1", AssignedReviewers: []string{"u4"}, CreatedAt: now.Add(-20 * 24 * time.Hour)},
+		// чужой автор не учитывается
+		{ID: "old-4", AuthorID: "u3", AssignedReviewers: []string{"u5"}, CreatedAt: now.Add(-time.Hour)},
+	}
+	for _, pr := range history {
+		pr.Status = domain.PRStatusMerged
+		store.prs[pr.ID] = pr
+	}
+
+	svc := newTestPRService(store, StrategyPairRotation)
+	svc.now = func() time.Time { return now }
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u4" || pr.AssignedReviewers[1] != "u5" {
+		t.Fatalf("reviewers = %#v, want [u4 u5]", pr.AssignedReviewers)
+	}
+
+	explained, err := svc.ExplainAssignment(context.Background(), nil, "pr-1")
+	if err != nil {
+		t.Fatalf("ExplainAssignment() error = %v", err)
+	}
+	for _, c := range explained[0].Rounds[0].Candidates {
+		if c.UserID == "u3" && (c.PairScore < 0.49 || c.PairScore > 0.51) {
+			t.Fatalf("u3 pair score = %v, want 0.5", c.PairScore)
+		}
+	}
+}
+
+func TestCreatePRWithAutoAssign_SeniorityPolicy(t *testing.T) {
+	store := newFakeStore()
+
+	one := 1
+	settings := domain.DefaultTeamSettings()
+	settings.ReviewersCount = 3
+	settings.MinSeniorReviewers = 1
+	settings.MaxJuniorReviewers = &one
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true, Seniority: domain.SeniorityJunior},
+		domain.User{ID: "u3", IsActive: true, Seniority: domain.SeniorityJunior},
+		domain.User{ID: "u4", IsActive: true, Seniority: domain.SeniorityMiddle},
+		domain.User{ID: "u5", IsActive: true, Seniority: domain.SenioritySenior},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 3 || pr.AssignedReviewers[0] != "u5" || pr.AssignedReviewers[1] != "u2" || pr.AssignedReviewers[2] != "u4" {
+		t.Fatalf("reviewers = %#v, want [u5 u2 u4]", pr.AssignedReviewers)
+	}
+
+	found := false
+	for _, sk := range pr.SkippedReviewers {
+		if sk.UserID == "u3" && strings.Contains(sk.Reason, "junior reviewer limit") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected u3 skipped by junior limit, got %#v", pr.SkippedReviewers)
+	}
+}
+
+func TestCreatePRWithAutoAssign_SeniorityFallbacks(t *testing.T) {
+	setup := func(fallback domain.SeniorityFallback) *PRService {
+		store := newFakeStore()
+
+		settings := domain.DefaultTeamSettings()
+		settings.MinSeniorReviewers = 1
+		settings.SeniorityFallback = fallback
+		store.addTeam("backend", settings,
+			domain.User{ID: "u1", IsActive: true},
+			domain.User{ID: "u2", IsActive: true, Seniority: domain.SeniorityJunior},
+			domain.User{ID: "u3", IsActive: true, Seniority: domain.SeniorityMiddle},
+		)
+
+		return newTestPRService(store, StrategyRandom)
+	}
+	ctx := context.Background()
+	in := CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}
+
+	_, err := setup(domain.SeniorityFallbackReject).CreatePRWithAutoAssign(ctx, in)
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeSeniorityUnsatisfied {
+		t.Fatalf("expected SENIORITY_POLICY_UNSATISFIED, got %v", err)
+	}
+
+	pr, err := setup(domain.SeniorityFallbackLeaveEmpty).CreatePRWithAutoAssign(ctx, in)
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || !strings.Contains(pr.AssignmentReason, "senior") {
+		t.Fatalf("expected one reviewer and a senior slot left empty, got %#v (%q)", pr.AssignedReviewers, pr.AssignmentReason)
+	}
+
+	pr, err = setup(domain.SeniorityFallbackIgnore).CreatePRWithAutoAssign(ctx, in)
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("expected policy relaxed to fill both slots, got %#v", pr.AssignedReviewers)
+	}
+}
+
+func TestReassignReviewer_KeepsSeniorReviewer(t *testing.T) {
+	store := newFakeStore()
+
+	settings := domain.DefaultTeamSettings()
+	settings.MinSeniorReviewers = 1
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true, Seniority: domain.SeniorityJunior},
+		domain.User{ID: "u3", IsActive: true, Seniority: domain.SeniorityMiddle},
+		domain.User{ID: "u5", IsActive: true, Seniority: domain.SenioritySenior},
+		domain.User{ID: "u6", IsActive: true, Seniority: domain.SeniorityLead},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u5" || pr.AssignedReviewers[1] != "u2" {
+		t.Fatalf("reviewers = %#v, want [u5 u2]", pr.AssignedReviewers)
+	}
+
+	_, newID, err := svc.ReassignReviewer(ctx, "pr-1", "u5")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "u6" {
+		t.Fatalf("expected senior replacement u6, got %s", newID)
+	}
+
+	_, newID, err = svc.ReassignReviewer(ctx, "pr-1", "u2")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "u3" {
+		t.Fatalf("expected u3 once a senior is assigned, got %s", newID)
+	}
+}
+
+func TestCreatePRWithAutoAssign_RequestedReviewers(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: false},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1", Reviewers: []string{"p1"}})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "p1" || pr.AssignedReviewers[1] != "u2" {
+		t.Fatalf("reviewers = %#v, want [p1 u2]", pr.AssignedReviewers)
+	}
+	if !strings.HasPrefix(pr.AssignmentReason, "requested by author: p1") {
+		t.Fatalf("unexpected reason %q", pr.AssignmentReason)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		reviewers []string
+		code      domain.ErrorCode
+	}{
+		{"author", []string{"u1"}, domain.ErrorCodeInvalidArgument},
+		{"duplicate", []string{"u2", "u2"}, domain.ErrorCodeInvalidArgument},
+		{"too many", []string{"u2", "u3", "p1"}, domain.ErrorCodeInvalidArgument},
+		{"inactive", []string{"u4"}, domain.ErrorCodeInvalidArgument},
+		{"unknown", []string{"ghost"}, domain.ErrorCodeNotFound},
+	} {
+		_, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-" + tc.name, Name: "Add feature", AuthorID: "u1", Reviewers: tc.reviewers})
+		if de, ok := domain.AsDomainError(err); !ok || de.Code != tc.code {
+			t.Errorf("%s: expected %s, got %v", tc.name, tc.code, err)
+		}
+	}
+}
+
+func TestAddAndRemoveReviewer(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	if _, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}); err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	_, err := svc.AddReviewer(ctx, "pr-1", "u4")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for full PR, got %v", err)
+	}
+
+	pr, err := svc.RemoveReviewer(ctx, "pr-1", "u2")
+	if err != nil {
+		t.Fatalf("RemoveReviewer() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u3" {
+		t.Fatalf("reviewers = %#v, want [u3]", pr.AssignedReviewers)
+	}
+
+	pr, err = svc.AddReviewer(ctx, "pr-1", "u4")
+	if err != nil {
+		t.Fatalf("AddReviewer() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || !pr.HasReviewer("u4") || !store.prs["pr-1"].HasReviewer("u4") {
+		t.Fatalf("expected u4 assigned, got %#v", pr.AssignedReviewers)
+	}
+
+	_, err = svc.RemoveReviewer(ctx, "pr-1", "u2")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotAssigned {
+		t.Fatalf("expected NOT_ASSIGNED, got %v", err)
+	}
+
+	store.prs["pr-1"].Status = domain.PRStatusMerged
+	_, err = svc.RemoveReviewer(ctx, "pr-1", "u4")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodePRMerged {
+		t.Fatalf("expected PR_MERGED, got %v", err)
+	}
+}
+
+func TestReassignReviewer_UsesAuthorTeamPolicy(t *testing.T) {
+	store := newFakeStore()
+
+	backend := domain.DefaultTeamSettings()
+	backend.ReviewersCount = 1
+	backend.MinSeniorReviewers = 1
+	backend.FallbackTeams = []string{"platform"}
+	store.addTeam("backend", backend,
+		domain.User{ID: "u1", IsActive: true},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: true, Seniority: domain.SenioritySenior},
+		domain.User{ID: "p2", IsActive: true, Seniority: domain.SeniorityJunior},
+		domain.User{ID: "p3", IsActive: true, Seniority: domain.SenioritySenior},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "p1" {
+		t.Fatalf("reviewers = %#v, want [p1]", pr.AssignedReviewers)
+	}
+
+	// У platform нет политики уровней, но замена подчиняется политике команды автора.
+	_, newID, err := svc.ReassignReviewer(ctx, "pr-1", "p1")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if newID != "p3" {
+		t.Fatalf("expected senior replacement p3, got %s", newID)
+	}
+}
+
+func TestReassignReviewerTo_ChecksChosenReviewer(t *testing.T) {
+	store := newFakeStore()
+
+	backend := domain.DefaultTeamSettings()
+	backend.FallbackTeams = []string{"platform"}
+	store.addTeam("backend", backend,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		domain.User{ID: "u5", IsActive: false},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: true},
+	)
+	store.addTeam("mobile", domain.DefaultTeamSettings(),
+		domain.User{ID: "m1", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	if _, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}); err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	for _, tc := range []struct {
+		newID string
+		code  domain.ErrorCode
+	}{
+		{"u1", domain.ErrorCodeNoCandidate},
+		{"u3", domain.ErrorCodeNoCandidate},
+		{"u5", domain.ErrorCodeNoCandidate},
+		{"m1", domain.ErrorCodeNoCandidate},
+		{"ghost", domain.ErrorCodeNotFound},
+	} {
+		_, _, err := svc.ReassignReviewerTo(ctx, "pr-1", "u2", tc.newID)
+		if de, ok := domain.AsDomainError(err); !ok || de.Code != tc.code {
+			t.Errorf("new_user_id %s: expected %s, got %v", tc.newID, tc.code, err)
+		}
+	}
+
+	pr, newID, err := svc.ReassignReviewerTo(ctx, "pr-1", "u2", "p1")
+	if err != nil {
+		t.Fatalf("ReassignReviewerTo() error = %v", err)
+	}
+	if newID != "p1" || pr.FallbackReviewers["p1"] != "platform" {
+		t.Fatalf("expected fallback replacement p1, got %s (%#v)", newID, pr.FallbackReviewers)
+	}
+
+	_, _, err = svc.ReassignReviewerTo(ctx, "pr-1", "u4", "u2")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotAssigned {
+		t.Fatalf("expected NOT_ASSIGNED, got %v", err)
+	}
+
+	explained, err := svc.ExplainAssignment(ctx, nil, "pr-1")
+	if err != nil {
+		t.Fatalf("ExplainAssignment() error = %v", err)
+	}
+	last := explained[len(explained)-1]
+	if last.Strategy != StrategyManual || !last.Reproduced || len(last.Reviewers) != 1 || last.Reviewers[0] != "p1" {
+		t.Fatalf("unexpected manual explanation: %+v", last)
+	}
+}
+
+func TestReassignReviewerTo_SeniorityFallbacks(t *testing.T) {
+	setup := func(fallback domain.SeniorityFallback) *PRService {
+		store := newFakeStore()
+
+		settings := domain.DefaultTeamSettings()
+		settings.MinSeniorReviewers = 1
+		settings.SeniorityFallback = fallback
+		store.addTeam("backend", settings,
+			domain.User{ID: "u1", IsActive: true},
+			domain.User{ID: "u2", IsActive: true, Seniority: domain.SeniorityJunior},
+			domain.User{ID: "u3", IsActive: true, Seniority: domain.SeniorityMiddle},
+			domain.User{ID: "u5", IsActive: true, Seniority: domain.SenioritySenior},
+		)
+
+		svc := newTestPRService(store, StrategyRandom)
+		if _, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}); err != nil {
+			t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+		}
+		return svc
+	}
+	ctx := context.Background()
+
+	// замена единственного senior на middle нарушает MinSeniorReviewers
+	_, _, err := setup(domain.SeniorityFallbackReject).ReassignReviewerTo(ctx, "pr-1", "u5", "u3")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeSeniorityUnsatisfied {
+		t.Fatalf("reject: expected SENIORITY_POLICY_UNSATISFIED, got %v", err)
+	}
+
+	_, _, err = setup(domain.SeniorityFallbackLeaveEmpty).ReassignReviewerTo(ctx, "pr-1", "u5", "u3")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("leave_empty: expected NO_CANDIDATE, got %v", err)
+	}
+
+	_, newID, err := setup(domain.SeniorityFallbackIgnore).ReassignReviewerTo(ctx, "pr-1", "u5", "u3")
+	if err != nil {
+		t.Fatalf("ignore: ReassignReviewerTo() error = %v", err)
+	}
+	if newID != "u3" {
+		t.Fatalf("ignore: expected u3, got %s", newID)
+	}
+}
+
+func TestReassignReviewerTo_ChecksAvailabilityAndCapacity(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	capacity := 1
+
+	backend := domain.DefaultTeamSettings()
+	backend.DefaultReviewCapacity = &capacity
+	backend.CapacityPolicy = domain.CapacityPolicyNoCandidate
+
+	store := newFakeStore()
+	store.addTeam("backend", backend,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		domain.User{ID: "u5", IsActive: true},
+	)
+	store.prs["pr-0"] = &domain.PullRequest{ID: "pr-0", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u4"}}
+	store.away = []domain.Unavailability{
+		{UserID: "u5", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(24 * time.Hour), Reason: "vacation"},
+	}
+
+	svc := newTestPRService(store, StrategyRandom)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u2" || pr.AssignedReviewers[1] != "u3" {
+		t.Fatalf("reviewers = %#v, want [u2 u3]", pr.AssignedReviewers)
+	}
+
+	for _, tc := range []struct {
+		newID  string
+		reason string
+	}{
+		{"u5", "unavailable until"},
+		{"u4", "at review capacity (1/1 open reviews)"},
+	} {
+		_, _, err := svc.ReassignReviewerTo(ctx, "pr-1", "u2", tc.newID)
+		de, ok := domain.AsDomainError(err)
+		if !ok || de.Code != domain.ErrorCodeNoCandidate || !strings.Contains(de.Msg, tc.reason) {
+			t.Errorf("new_user_id %s: expected NO_CANDIDATE (%s), got %v", tc.newID, tc.reason, err)
+		}
+	}
+
+	// ASSIGN_ANYWAY разрешает назначить выбранного вручную ревьювера сверх лимита.
+	store.teams["backend"].Settings.CapacityPolicy = domain.CapacityPolicyAssignAnyway
+	if _, newID, err := svc.ReassignReviewerTo(ctx, "pr-1", "u2", "u4"); err != nil || newID != "u4" {
+		t.Fatalf("ReassignReviewerTo() = %s, %v; want u4", newID, err)
+	}
+}
+
+func TestCreateAndReassign_SkipExcludedReviewers(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		domain.User{ID: "u5", IsActive: true},
+	)
+	exclusion, err := domain.NewReviewerExclusion("u2", "u1", "manager")
+	if err != nil {
+		t.Fatalf("NewReviewerExclusion() error = %v", err)
+	}
+	store.exclusions = append(store.exclusions, *exclusion)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1", ExcludeReviewers: []string{"u3"}})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u4" || pr.AssignedReviewers[1] != "u5" {
+		t.Fatalf("reviewers = %#v, want [u4 u5]", pr.AssignedReviewers)
+	}
+	reasons := make(map[string]string)
+	for _, sk := range pr.SkippedReviewers {
+		reasons[sk.UserID] = sk.Reason
+	}
+	if reasons["u2"] != "conflict of interest with author: manager" || reasons["u3"] != "excluded for this PR" {
+		t.Fatalf("unexpected skipped reviewers %#v", pr.SkippedReviewers)
+	}
+
+	// исключения сохраняются в PR и действуют при переназначении
+	_, _, err = svc.ReassignReviewer(ctx, "pr-1", "u4")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE, got %v", err)
+	}
+	_, _, err = svc.ReassignReviewerTo(ctx, "pr-1", "u4", "u3")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE for excluded replacement, got %v", err)
+	}
+
+	_, err = svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-2", Name: "Add feature", AuthorID: "u1", Reviewers: []string{"u2"}})
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for requested excluded reviewer, got %v", err)
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	if _, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}); err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	pr, err := svc.SubmitReview(ctx, "pr-1", "u2", "changes_requested", " fix tests ")
+	if err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if rv := pr.Reviews["u2"]; rv.Verdict != domain.ReviewVerdictChangesRequested || rv.Message != "fix tests" {
+		t.Fatalf("unexpected review %#v", rv)
+	}
+
+	pr, err = svc.SubmitReview(ctx, "pr-1", "u2", "APPROVED", "")
+	if err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if store.prs["pr-1"].Reviews["u2"].Verdict != domain.ReviewVerdictApproved || len(pr.Reviews) != 1 {
+		t.Fatalf("expected latest verdict APPROVED, got %#v", store.prs["pr-1"].Reviews)
+	}
+
+	_, err = svc.SubmitReview(ctx, "pr-1", "u4", "APPROVED", "")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotAssigned {
+		t.Fatalf("expected NOT_ASSIGNED, got %v", err)
+	}
+
+	_, err = svc.SubmitReview(ctx, "pr-1", "u3", "LGTM", "")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT, got %v", err)
+	}
+
+	// после переназначения вердикт прежнего ревьювера больше не показывается
+	if _, _, err := svc.ReassignReviewer(ctx, "pr-1", "u2"); err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if _, ok := store.prs["pr-1"].Reviews["u2"]; ok {
+		t.Fatalf("expected verdict of replaced reviewer to be dropped")
+	}
+
+	store.prs["pr-1"].Status = domain.PRStatusMerged
+	_, err = svc.SubmitReview(ctx, "pr-1", "u3", "APPROVED", "")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodePRMerged {
+		t.Fatalf("expected PR_MERGED, got %v", err)
+	}
+}
+
+func TestMergePR_ApprovalPolicy(t *testing.T) {
+	store := newFakeStore()
+	settings := domain.DefaultTeamSettings()
+	settings.MinApprovals = 2
+	settings.BlockOnChangesRequested = true
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	for _, id := range []string{"pr-1", "pr-2"} {
+		if _, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: id, Name: "Add feature", AuthorID: "u1"}); err != nil {
+			t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+		}
+	}
+
+	_, err := svc.SubmitReview(ctx, "pr-1", "u2", "APPROVED", "")
+	if err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if _, err := svc.SubmitReview(ctx, "pr-1", "u3", "CHANGES_REQUESTED", ""); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+
+	_, err = svc.MergePR(ctx, "pr-1", false)
+	de, ok := domain.AsDomainError(err)
+	if !ok || de.Code != domain.ErrorCodeMergeBlocked {
+		t.Fatalf("expected MERGE_BLOCKED, got %v", err)
+	}
+	if !strings.Contains(de.Msg, "2 approval(s) required, have 1") || !strings.Contains(de.Msg, "changes requested by u3") {
+		t.Fatalf("unexpected message %q", de.Msg)
+	}
+
+	if _, err := svc.SubmitReview(ctx, "pr-1", "u3", "APPROVED", ""); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	pr, err := svc.MergePR(ctx, "pr-1", false)
+	if err != nil {
+		t.Fatalf("MergePR() error = %v", err)
+	}
+	if pr.Status != domain.PRStatusMerged || len(store.events) != 0 {
+		t.Fatalf("expected plain merge without events, got %s / %#v", pr.Status, store.events)
+	}
+
+	pr, err = svc.MergePR(ctx, "pr-2", true)
+	if err != nil {
+		t.Fatalf("MergePR(force) error = %v", err)
+	}
+	if pr.Status != domain.PRStatusMerged || store.prs["pr-2"].Status != domain.PRStatusMerged {
+		t.Fatalf("expected pr-2 merged")
+	}
+	if len(store.events) != 1 || store.events[0].EventType != repository.PREventTypeForceMerged ||
+		!strings.Contains(store.events[0].Details, "2 approval(s) required, have 0") {
+		t.Fatalf("unexpected events %#v", store.events)
+	}
+}
+
+func TestDraftPR_AssignsReviewersWhenReady(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	_, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "WIP", AuthorID: "u1", Draft: true, Reviewers: []string{"u2"}})
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for draft with reviewers, got %v", err)
+	}
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "WIP", AuthorID: "u1", Draft: true})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if pr.Status != domain.PRStatusDraft || len(pr.AssignedReviewers) != 0 || len(store.explained) != 0 {
+		t.Fatalf("expected draft without reviewers, got %s %#v", pr.Status, pr.AssignedReviewers)
+	}
+
+	_, err = svc.MergePR(ctx, "pr-1", false)
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidPRStatus {
+		t.Fatalf("expected INVALID_PR_STATUS on merge of draft, got %v", err)
+	}
+
+	pr, err = svc.MarkReady(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("MarkReady() error = %v", err)
+	}
+	if pr.Status != domain.PRStatusOpen || len(store.prs["pr-1"].AssignedReviewers) != 2 || len(store.explained) != 1 {
+		t.Fatalf("expected OPEN with 2 reviewers, got %s %#v", pr.Status, store.prs["pr-1"].AssignedReviewers)
+	}
+
+	pr, err = svc.ClosePR(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("ClosePR() error = %v", err)
+	}
+	if pr.Status != domain.PRStatusClosed || len(store.prs["pr-1"].AssignedReviewers) != 0 || store.prs["pr-1"].ClosedAt == nil {
+		t.Fatalf("expected CLOSED without reviewers, got %s %#v", pr.Status, store.prs["pr-1"].AssignedReviewers)
+	}
+
+	_, _, err = svc.ReassignReviewer(ctx, "pr-1", "u2")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidPRStatus {
+		t.Fatalf("expected INVALID_PR_STATUS on reassign of closed PR, got %v", err)
+	}
+
+	pr, err = svc.ReopenPR(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("ReopenPR() error = %v", err)
+	}
+	if pr.Status != domain.PRStatusDraft {
+		t.Fatalf("expected DRAFT after reopen, got %s", pr.Status)
+	}
+}
+
+func TestCreatePR_LargePRGetsExtraReviewer(t *testing.T) {
+	settings := domain.DefaultTeamSettings()
+	settings.LargePRLines = 500
+
+	store := newFakeStore()
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	_, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{
+		ID: "pr-bad", Name: "Bad", AuthorID: "u1",
+		Metadata: domain.PRMetadata{SourceBranch: "main", TargetBranch: "main"},
+	})
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for same branches, got %v", err)
+	}
+
+	small, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{
+		ID: "pr-small", Name: "Small", AuthorID: "u1",
+		Metadata: domain.PRMetadata{LinesAdded: 500},
+	})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if small.ReviewersRequired != 2 || len(small.AssignedReviewers) != 2 {
+		t.Fatalf("expected 2 reviewers for small PR, got %d %#v", small.ReviewersRequired, small.AssignedReviewers)
+	}
+
+	large, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{
+		ID: "pr-large", Name: "Large", AuthorID: "u1",
+		Metadata: domain.PRMetadata{Repository: "backend/api", LinesAdded: 400, LinesDeleted: 101},
+	})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if large.ReviewersRequired != 3 || len(large.AssignedReviewers) != 3 {
+		t.Fatalf("expected 3 reviewers for large PR, got %d %#v", large.ReviewersRequired, large.AssignedReviewers)
+	}
+	if got := store.prs["pr-large"].Metadata.Repository; got != "backend/api" {
+		t.Fatalf("expected stored repository, got %q", got)
+	}
+}
+
+func TestCreatePR_LabelRouting(t *testing.T) {
+	settings := domain.DefaultTeamSettings()
+	settings.LabelRules = []domain.LabelRule{
+		{Label: "security", RequireTeam: "security"},
+		{Label: "docs", ReviewersCount: 1},
+	}
+
+	store := newFakeStore()
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+	store.addTeam("security", domain.DefaultTeamSettings(),
+		domain.User{ID: "s1", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-sec", Name: "Auth", AuthorID: "u1", Labels: []string{"Security"}})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if pr.ReviewersRequired != 3 || len(pr.AssignedReviewers) != 3 || pr.AssignedReviewers[0] != "s1" {
+		t.Fatalf("expected security reviewer plus 2 from team, got %d %#v", pr.ReviewersRequired, pr.AssignedReviewers)
+	}
+	if got := store.prs["pr-sec"].Labels; len(got) != 1 || got[0] != "security" {
+		t.Fatalf("expected stored labels [security], got %#v", got)
+	}
+
+	// Замена ревьювера из команды security берётся снова из неё.
+	_, newID, err := svc.ReassignReviewer(ctx, "pr-sec", "s1")
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected NO_CANDIDATE without another security reviewer, got %q %v", newID, err)
+	}
+
+	pr, err = svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-docs", Name: "Docs", AuthorID: "u1", Labels: []string{"docs"}})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if pr.ReviewersRequired != 1 || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("expected 1 reviewer for docs PR, got %d %#v", pr.ReviewersRequired, pr.AssignedReviewers)
+	}
+
+	pr, err = svc.SetLabels(ctx, "pr-docs", []string{"docs", "Typo"})
+	if err != nil {
+		t.Fatalf("SetLabels() error = %v", err)
+	}
+	if got := store.prs["pr-docs"].Labels; len(got) != 2 || got[1] != "typo" || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("expected labels [docs typo] and unchanged reviewers, got %#v %#v", got, pr.AssignedReviewers)
+	}
+}
+
+func TestCreatePR_LocksAllCandidatesOnce(t *testing.T) {
+	backend := domain.DefaultTeamSettings()
+	backend.FallbackTeams = []string{"platform"}
+	backend.LabelRules = []domain.LabelRule{{Label: "security", RequireTeam: "security"}}
+
+	store := newFakeStore()
+	store.addTeam("backend", backend,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+	)
+	store.addTeam("security", domain.DefaultTeamSettings(),
+		domain.User{ID: "s1", IsActive: true},
+	)
+	store.addTeam("platform", domain.DefaultTeamSettings(),
+		domain.User{ID: "p1", IsActive: true},
+		domain.User{ID: "a1", IsActive: true},
+	)
+	store.codeOwners = []domain.CodeOwnerRule{{Pattern: "/api/", Users: []string{"p1"}}}
+
+	svc := newTestPRService(store, StrategyRandom)
+
+	pr, err := svc.CreatePRWithAutoAssign(context.Background(), CreatePRInput{
+		ID:           "pr-1",
+		Name:         "Auth",
+		AuthorID:     "u1",
+		Labels:       []string{"security"},
+		ChangedFiles: []string{"api/auth.go"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if len(pr.AssignedReviewers) != 3 {
+		t.Fatalf("expected 3 reviewers, got %#v", pr.AssignedReviewers)
+	}
+
+	// Кандидаты из пулов меток, владельцев кода, домашней и fallback-команд
+	// блокируются одним вызовом, отсортированными и без повторов.
+	want := []string{"a1", "p1", "s1", "u3"}
+	if len(store.locks) != 1 || !slices.Equal(store.locks[0], want) {
+		t.Fatalf("expected single lock of %v, got %v", want, store.locks)
+	}
+}
+
+func TestReviewSLA_DeadlinesAndOverdue(t *testing.T) {
+	settings := domain.DefaultTeamSettings()
+	settings.ReviewSLAHours = 2
+
+	store := newFakeStore()
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	// пятница 16:00: до конца рабочего дня ровно 2 часа
+	friday := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return friday }
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	wantDue := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	for _, id := range pr.AssignedReviewers {
+		if due := pr.ReviewDueAt[id]; !due.Equal(wantDue) {
+			t.Fatalf("expected due %v for %s, got %v", wantDue, id, due)
+		}
+	}
+
+	if _, err := svc.SubmitReview(ctx, "pr-1", "u2", "APPROVED", ""); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+
+	monday := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return monday }
+
+	overdue, err := svc.ListOverdueReviews(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListOverdueReviews() error = %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].TeamName != "backend" ||
+		len(overdue[0].Reviewers) != 1 || overdue[0].Reviewers[0].UserID != "u3" {
+		t.Fatalf("expected only u3 overdue in backend, got %#v", overdue)
+	}
+	if got := overdue[0].Reviewers[0].Reviews[0].Overdue; got != 64*time.Hour {
+		t.Fatalf("expected 64h overdue, got %v", got)
+	}
+
+	// новый ревьювер получает свой срок от момента переназначения
+	pr, _, err = svc.ReassignReviewer(ctx, "pr-1", "u3")
+	if err != nil {
+		t.Fatalf("ReassignReviewer() error = %v", err)
+	}
+	if due := pr.ReviewDueAt["u4"]; !due.Equal(monday.Add(2 * time.Hour)) {
+		t.Fatalf("expected due of new reviewer at 12:00 monday, got %v", due)
+	}
+
+	overdue, err = svc.ListOverdueReviews(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListOverdueReviews() error = %v", err)
+	}
+	if len(overdue) != 0 {
+		t.Fatalf("expected no overdue reviews after reassign, got %#v", overdue)
+	}
+}
+
+func TestCreatePR_HotfixPrefersLeastLoadedAvailable(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		// u5 сейчас не работает
+		domain.User{ID: "u5", IsActive: true, WorkingHours: &domain.WorkingHours{Start: 0, End: 60}},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	// u2 и u3 получают по открытому ревью
+	if _, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-0", Name: "Refactor", AuthorID: "u1"}); err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+
+	normal, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Refactor more", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if got := normal.AssignedReviewers; len(got) != 2 || got[0] != "u2" || got[1] != "u3" {
+		t.Fatalf("expected team strategy to pick u2,u3 for normal PR, got %#v", got)
+	}
+
+	hotfix, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-2", Name: "Fix outage", AuthorID: "u1", Priority: "HOTFIX"})
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if hotfix.Priority != domain.PRPriorityHotfix {
+		t.Fatalf("expected hotfix priority, got %q", hotfix.Priority)
+	}
+	if got := hotfix.AssignedReviewers; len(got) != 2 || got[0] != "u4" || slices.Contains(got, "u5") {
+		t.Fatalf("expected least loaded reviewer in working hours first (u4) and no u5, got %#v", got)
+	}
+	if store.explained[len(store.explained)-1].Strategy != StrategyLeastLoaded {
+		t.Fatalf("expected hotfix assignment to use least_loaded, got %q", store.explained[len(store.explained)-1].Strategy)
+	}
+
+	_, err = svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-3", Name: "Bad", AuthorID: "u1", Priority: "urgent"})
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeInvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for unknown priority, got %v", err)
+	}
+}
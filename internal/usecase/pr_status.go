@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// MarkReady переводит DRAFT PR в OPEN, пересчитывает число ревьюверов и назначает их
+// так же, как при создании.
+func (s *PRService) MarkReady(
+	ctx context.Context,
+	prID string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		if err := pr.MarkReady(); err != nil {
+			return err
+		}
+
+		author, err := s.users.GetUserByID(ctx, exec, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		team, err := s.teams.GetTeamWithMembers(ctx, exec, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		// Метки и настройки команды могли измениться, пока PR был черновиком.
+		if err := pr.SetReviewersRequired(team.Settings.ReviewersFor(pr.Metadata, pr.Labels)); err != nil {
+			return err
+		}
+
+		req, picked, err := s.planReviewers(ctx, exec, pr, author, team, nil, planOptions{})
+		if err != nil {
+			return err
+		}
+		if len(pr.UncoveredSkills) > 0 {
+			s.logger.Warn("pr_required_skills_uncovered", "pr_id", pr.ID, "skills", pr.UncoveredSkills)
+		}
+
+		if err := s.prs.SetStatus(ctx, exec, pr); err != nil {
+			return err
+		}
+		if err := s.prs.AssignReviewers(ctx, exec, pr, s.now()); err != nil {
+			return err
+		}
+
+		explanation := s.explainAssignment(domain.AssignmentKindCreate, req, picked, "")
+		if err := s.prs.AddAssignmentExplanation(ctx, exec, explanation); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_mark_ready_usecase_failed", "pr_id", prID, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ClosePR закрывает DRAFT или OPEN PR без merge и снимает ревьюверов.
+func (s *PRService) ClosePR(
+	ctx context.Context,
+	prID string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		now := s.now()
+		if err := pr.Close(now); err != nil {
+			return err
+		}
+
+		if err := s.prs.SetStatus(ctx, exec, pr); err != nil {
+			return err
+		}
+		if err := s.prs.ReleaseReviewers(ctx, exec, pr.ID, now); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_close_usecase_failed", "pr_id", prID, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReopenPR возвращает закрытый PR в DRAFT.
+func (s *PRService) ReopenPR(
+	ctx context.Context,
+	prID string,
+) (*domain.PullRequest, error) {
+	var result *domain.PullRequest
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		pr, _, err := s.prs.GetPRForUpdate(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		if err := pr.Reopen(); err != nil {
+			return err
+		}
+
+		if err := s.prs.SetStatus(ctx, exec, pr); err != nil {
+			return err
+		}
+
+		result = pr
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_reopen_usecase_failed", "pr_id", prID, "err", err)
+		return nil, err
+	}
+
+	return result, nil
+}
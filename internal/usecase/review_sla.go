@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// reviewDueAt считает срок ревью reviewerID, назначенного в at, по SLA команды автора.
+func (s *PRService) reviewDueAt(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	authorTeam *domain.Team,
+	reviewerID string,
+	at time.Time,
+) (*time.Time, error) {
+	if authorTeam.Settings.ReviewSLAHours <= 0 {
+		return nil, nil
+	}
+	reviewer, err := s.users.GetUserByID(ctx, exec, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	return authorTeam.Settings.ReviewDueAt(*reviewer, at), nil
+}
+
+// setReviewDeadlines проставляет сроки ревью всем назначенным ревьюверам PR.
+func (s *PRService) setReviewDeadlines(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pr *domain.PullRequest,
+	authorTeam *domain.Team,
+) error {
+	now := s.now()
+	for _, id := range pr.AssignedReviewers {
+		due, err := s.reviewDueAt(ctx, exec, authorTeam, id, now)
+		if err != nil {
+			return err
+		}
+		pr.SetReviewDueAt(id, due)
+	}
+	return nil
+}
+
+// authorTeam возвращает команду автора PR; known переиспользуется, если это она.
+func (s *PRService) authorTeam(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	author *domain.User,
+	known *domain.Team,
+) (*domain.Team, error) {
+	if known != nil && known.Name == author.TeamName {
+		return known, nil
+	}
+	return s.teams.GetTeamWithMembers(ctx, exec, author.TeamName)
+}
+
+// OverdueReview — просроченное ревью одного PR.
+type OverdueReview struct {
+	PRID       string
+	PRName     string
+	AuthorID   string
+	AssignedAt time.Time
+	DueAt      time.Time
+	// Overdue — на сколько просрочено на момент запроса.
+	Overdue time.Duration
+}
+
+// OverdueReviewer — просроченные ревью одного ревьювера, самые старые первыми.
+type OverdueReviewer struct {
+	UserID  string
+	Reviews []OverdueReview
+}
+
+// OverdueTeam — ревьюверы команды с просроченными ревью.
+type OverdueTeam struct {
+	TeamName  string
+	Reviewers []OverdueReviewer
+}
+
+// ListOverdueReviews возвращает ревью открытых PR, срок которых прошёл, а вердикта
+// после назначения нет, сгруппированные по команде ревьювера и ревьюверу.
+func (s *PRService) ListOverdueReviews(
+	ctx context.Context,
+	exec repository.DBExecutor,
+) ([]OverdueTeam, error) {
+	now := s.now()
+	rows, err := s.prs.ListOverdueReviews(ctx, exec, now)
+	if err != nil {
+		s.logger.Error("pr_list_overdue_reviews_usecase_failed", "err", err)
+		return nil, err
+	}
+
+	// rows упорядочены по команде, ревьюверу и сроку.
+	out := make([]OverdueTeam, 0)
+	for _, r := range rows {
+		if n := len(out); n == 0 || out[n-1].TeamName != r.TeamName {
+			out = append(out, OverdueTeam{TeamName: r.TeamName})
+		}
+		team := &out[len(out)-1]
+		if n := len(team.Reviewers); n == 0 || team.Reviewers[n-1].UserID != r.ReviewerID {
+			team.Reviewers = append(team.Reviewers, OverdueReviewer{UserID: r.ReviewerID})
+		}
+		reviewer := &team.Reviewers[len(team.Reviewers)-1]
+		reviewer.Reviews = append(reviewer.Reviews, OverdueReview{
+			PRID:       r.PRID,
+			PRName:     r.PRName,
+			AuthorID:   r.AuthorID,
+			AssignedAt: r.AssignedAt,
+			DueAt:      r.DueAt,
+			Overdue:    now.Sub(r.DueAt),
+		})
+	}
+	return out, nil
+}
+
+// ListStaleReviews возвращает назначения, которые пора переназначить (см. StaleReviewWorker.RunOnce).
+func (s *PRService) ListStaleReviews(
+	ctx context.Context,
+	exec repository.DBExecutor,
+) ([]repository.StaleReview, error) {
+	return s.prs.ListStaleReviews(ctx, exec, s.now())
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// reviewerConflicts возвращает id -> причину для тех, кого нельзя назначать ревьювером PR:
+// исключённых автором для этого PR и тех, кто состоит с автором в паре-исключении.
+func (s *PRService) reviewerConflicts(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pr *domain.PullRequest,
+) (map[string]string, error) {
+	exclusions, err := s.users.ListReviewerExclusions(ctx, exec, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make(map[string]string, len(pr.ExcludedReviewers)+len(exclusions))
+	for _, id := range pr.ExcludedReviewers {
+		conflicts[id] = "excluded for this PR"
+	}
+	for _, e := range exclusions {
+		reason := "conflict of interest with author"
+		if e.Reason != "" {
+			reason += ": " + e.Reason
+		}
+		conflicts[e.Other(pr.AuthorID)] = reason
+	}
+	return conflicts, nil
+}
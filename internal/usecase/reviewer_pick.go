@@ -0,0 +1,794 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// pickRequest описывает, сколько ревьюверов нужно добрать и кого нельзя назначать.
+type pickRequest struct {
+	PR     *domain.PullRequest
+	Author *domain.User
+	// Home — команда, из которой в первую очередь берутся ревьюверы.
+	Home *domain.Team
+	// Assigned — уже назначенные на PR ревьюверы.
+	Assigned []string
+	// Exclude — пользователи, которых нельзя выбирать (автор, заменяемый ревьювер).
+	Exclude []string
+	Count   int
+	// RequiredSkills — навыки, которые ещё не покрыты назначенными ревьюверами.
+	RequiredSkills []string
+	// ChangedFiles — изменённые файлы; для каждого затронутого правила владения
+	// назначается хотя бы один владелец, если среди ревьюверов его ещё нет.
+	ChangedFiles []string
+	// Conflicts — id -> причина, по которой кандидата нельзя назначать (см. reviewerConflicts).
+	Conflicts map[string]string
+	// LabelTeams — команды, из каждой из которых метки PR требуют хотя бы одного ревьювера.
+	LabelTeams []domain.LabelTeam
+	// Seed — seed генератора стратегии; nil — новый (s.seed).
+	Seed *int64
+	// ReadOnly — предпросмотр: кандидаты не блокируются.
+	ReadOnly bool
+}
+
+type pickResult struct {
+	ReviewerIDs []string
+	// Fallback: id ревьювера -> fallback-команда, из которой он выбран.
+	Fallback map[string]string
+	Reason   string
+	// UncoveredSkills — навыки из RequiredSkills, которые не удалось покрыть.
+	UncoveredSkills []string
+	// Skipped — рассмотренные, но не выбранные кандидаты с причиной.
+	Skipped []domain.SkippedReviewer
+
+	// Strategy, Seed и Rounds позволяют объяснить и повторить выбор (см. ReplayAssignment).
+	Strategy string
+	Seed     int64
+	Rounds   []domain.SelectionRound
+
+	// pools — все рассмотренные пулы по порядку, для ранжирования кандидатов.
+	pools []*candidatePool
+}
+
+// candidatePool — кандидаты одной команды вместе с их текущей нагрузкой.
+type candidatePool struct {
+	team     *domain.Team
+	fallback bool
+	// excluded — id -> причина, по которой кандидат не рассматривается (конфликт интересов).
+	excluded map[string]string
+
+	underCapacity []domain.User
+	// offHours — кандидаты в пределах лимита, но вне рабочего времени (PreferWorkingHours).
+	offHours   []domain.User
+	atCapacity []domain.User
+	// candidates — отобранные кандидаты до блокировки (см. lockCandidatePools);
+	// capacityOf — их лимит открытых ревью.
+	candidates []domain.User
+	capacityOf func(domain.User) (int, bool)
+
+	// skipped — id -> причина, по которой кандидат не попал в underCapacity.
+	skipped      map[string]string
+	skippedOrder []string
+
+	load           map[string]int
+	lastAssignedAt map[string]time.Time
+}
+
+// available возвращает свободных кандидатов пула: в пределах лимита, затем вне рабочего
+// времени, затем (если это разрешает policy) сверх лимита — и пометку для причины выбора.
+func (p *candidatePool) available(taken map[string]struct{}, policy domain.CapacityPolicy) ([]domain.User, string) {
+	if candidates := freeCandidates(p.underCapacity, taken); len(candidates) > 0 {
+		return candidates, ""
+	}
+	if candidates := freeCandidates(p.offHours, taken); len(candidates) > 0 {
+		return candidates, ", outside working hours"
+	}
+	if assignsOverCapacity(policy) {
+		return freeCandidates(p.atCapacity, taken), ", over capacity"
+	}
+	return nil, ""
+}
+
+func (p *candidatePool) skip(id, reason string) {
+	if p.skipped == nil {
+		p.skipped = make(map[string]string)
+	}
+	if _, exists := p.skipped[id]; !exists {
+		p.skippedOrder = append(p.skippedOrder, id)
+	}
+	p.skipped[id] = reason
+}
+
+// pickReviewers добирает req.Count ревьюверов: сначала из домашней команды,
+// затем по порядку из её fallback-команд. Если включена PreferWorkingHours, кандидаты
+// вне рабочего времени рассматриваются после всех остальных; достигшие лимита открытых
+// ревью — в самом конце, согласно CapacityPolicy домашней команды.
+func (s *PRService) pickReviewers(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	req pickRequest,
+) (pickResult, error) {
+	res := pickResult{Fallback: make(map[string]string)}
+	if req.Seed != nil {
+		res.Seed = *req.Seed
+	} else {
+		res.Seed = s.seed()
+	}
+	now := s.now()
+	rng := s.rand(res.Seed)
+
+	taken := make(map[string]struct{}, len(req.Assigned)+len(req.Exclude))
+	for _, id := range req.Assigned {
+		taken[id] = struct{}{}
+	}
+	for _, id := range req.Exclude {
+		taken[id] = struct{}{}
+	}
+
+	selector := s.selectors.ForTeam(req.Home.Name)
+	var hotfix bool
+	if req.PR.Priority == domain.PRPriorityHotfix {
+		// На hotfix — наименее загруженные из тех, у кого сейчас рабочее время,
+		// независимо от стратегии команды.
+		hotfix = true
+		selector = &LeastLoadedSelector{}
+		home := *req.Home
+		home.Settings = home.Settings.ForPriority(req.PR.Priority)
+		req.Home = &home
+	}
+	if selector != nil {
+		res.Strategy = selector.Name()
+	}
+	teamNames := append([]string{req.Home.Name}, req.Home.Settings.FallbackTeams...)
+	reasons := make([]string, 0, len(teamNames)+1)
+	if hotfix {
+		reasons = append(reasons, "hotfix: least loaded reviewers in working hours first")
+	}
+	// pools — пулы домашней и fallback-команд, до которых дошёл выбор.
+	pools := make([]*candidatePool, 0, len(teamNames))
+
+	uncovered := domain.NormalizeSkills(req.RequiredSkills)
+
+	policy, err := s.seniorityPolicyFor(ctx, exec, req)
+	if err != nil {
+		return pickResult{}, err
+	}
+
+	run := func(pool *candidatePool, candidates []domain.User, count int, note string) (Selection, error) {
+		in := SelectionInput{
+			PR:             req.PR,
+			Author:         req.Author,
+			Candidates:     candidates,
+			Assigned:       append(append([]string(nil), req.Assigned...), res.ReviewerIDs...),
+			Count:          count,
+			Load:           pool.load,
+			LastAssignedAt: pool.lastAssignedAt,
+			Rand:           rng,
+		}
+		if selector != nil && selector.Name() == StrategyPairRotation {
+			scores, err := s.pairScores(ctx, exec, req.Author.ID, candidates, req.Home.Settings.PairRotationWindow(), now)
+			if err != nil {
+				return Selection{}, err
+			}
+			in.PairScore = scores
+		}
+		selection, err := s.runSelector(selector, in)
+		if err != nil {
+			return Selection{}, err
+		}
+		res.Rounds = append(res.Rounds, selectionRound(in, note, selection))
+
+		for _, id := range selection.ReviewerIDs {
+			taken[id] = struct{}{}
+			res.ReviewerIDs = append(res.ReviewerIDs, id)
+			if pool.fallback {
+				res.Fallback[id] = pool.team.Name
+			}
+			for _, c := range candidates {
+				if c.ID == id {
+					uncovered = withoutSkillsOf(uncovered, c)
+					policy.add(c)
+				}
+			}
+		}
+		return selection, nil
+	}
+
+	choose := func(pool *candidatePool, candidates []domain.User, note string) error {
+		prefix := note
+		if pool.fallback {
+			prefix = joinReason(prefix, "fallback team "+pool.team.Name)
+		}
+
+		// Сначала добираем senior-ревьюверов, которых требует политика команды,
+		// предпочитая тех, кто покрывает недостающие навыки.
+		for policy.needSeniors() > 0 && len(res.ReviewerIDs) < req.Count {
+			seniors := seniorCandidates(freeCandidates(candidates, taken))
+			if len(seniors) == 0 {
+				break
+			}
+			if best := bestSkillCover(seniors, uncovered); len(best) > 0 {
+				seniors = best
+			}
+			note := joinReason(prefix, "senior reviewer")
+			selection, err := run(pool, seniors, 1, note)
+			if err != nil {
+				return err
+			}
+			if len(selection.ReviewerIDs) == 0 {
+				break
+			}
+			reasons = append(reasons, joinReason(note, selection.Reason))
+		}
+
+		// Затем жадно покрываем недостающие навыки: из кандидатов, закрывающих
+		// больше всего непокрытых навыков, стратегия выбирает одного.
+		// Слоты, оставленные под senior-ревьюверов, не занимаем.
+		for len(uncovered) > 0 && len(res.ReviewerIDs) < req.Count-policy.needSeniors() {
+			best := bestSkillCover(policy.filter(pool, freeCandidates(candidates, taken)), uncovered)
+			if len(best) == 0 {
+				break
+			}
+			note := joinReason(prefix, "covers skills "+strings.Join(uncovered, ","))
+			selection, err := run(pool, best, 1, note)
+			if err != nil {
+				return err
+			}
+			if len(selection.ReviewerIDs) == 0 {
+				break
+			}
+			reasons = append(reasons, joinReason(note, selection.Reason))
+		}
+
+		// При лимите junior-ревьюверов выбираем по одному, чтобы пересчитывать лимит.
+		for {
+			remaining := req.Count - len(res.ReviewerIDs) - policy.needSeniors()
+			free := policy.filter(pool, freeCandidates(candidates, taken))
+			if remaining <= 0 || len(free) == 0 {
+				return nil
+			}
+
+			count := remaining
+			if policy.limitsJuniors() {
+				count = 1
+			}
+			selection, err := run(pool, free, count, prefix)
+			if err != nil {
+				return err
+			}
+			reasons = append(reasons, joinReason(prefix, selection.Reason))
+			if len(selection.ReviewerIDs) == 0 || !policy.limitsJuniors() {
+				return nil
+			}
+		}
+	}
+
+	// Пулы всех этапов собираются заранее, чтобы заблокировать их кандидатов одним запросом.
+	labelPools, err := s.labelTeamPools(ctx, exec, req, taken, now)
+	if err != nil {
+		return pickResult{}, err
+	}
+	owners, err := s.codeOwnerPools(ctx, exec, req, taken, now)
+	if err != nil {
+		return pickResult{}, err
+	}
+	teamPools, err := s.teamPools(ctx, exec, req, teamNames, taken, now)
+	if err != nil {
+		return pickResult{}, err
+	}
+
+	all := make([]*candidatePool, 0, len(labelPools)+len(owners)+len(teamPools))
+	for _, lp := range labelPools {
+		all = append(all, lp.pool)
+	}
+	for _, op := range owners {
+		all = append(all, op.pool)
+	}
+	all = append(all, teamPools...)
+	if err := s.lockCandidatePools(ctx, exec, all, req.ReadOnly, req.Home.Settings, now); err != nil {
+		return pickResult{}, err
+	}
+
+	// Метки PR могут требовать ревьювера из определённой команды: такие слоты заполняются первыми.
+	for _, lp := range labelPools {
+		if len(res.ReviewerIDs) >= req.Count {
+			break
+		}
+		if lp.coveredBy(req.Assigned, req.Exclude, res.ReviewerIDs) {
+			continue
+		}
+
+		candidates, suffix := lp.pool.available(taken, req.Home.Settings.CapacityPolicy)
+		note := "label " + lp.label.Label + ": team " + lp.label.Team + suffix
+		candidates = policy.filter(lp.pool, candidates)
+		if best := bestSkillCover(candidates, uncovered); len(best) > 0 {
+			candidates = best
+		}
+		if len(candidates) == 0 {
+			s.logger.Warn("label_team_uncovered", "pr_id", req.PR.ID, "label", lp.label.Label, "required_team", lp.label.Team)
+			reasons = append(reasons, "no available reviewer from team "+lp.label.Team+" for label "+lp.label.Label)
+			continue
+		}
+
+		selection, err := run(lp.pool, candidates, 1, note)
+		if err != nil {
+			return pickResult{}, err
+		}
+		reasons = append(reasons, joinReason(note, selection.Reason))
+	}
+
+	for _, op := range owners {
+		if len(res.ReviewerIDs) >= req.Count {
+			break
+		}
+		if op.coveredBy(req.Assigned, req.Exclude, res.ReviewerIDs) {
+			continue
+		}
+
+		candidates, suffix := op.pool.available(taken, req.Home.Settings.CapacityPolicy)
+		note := "code owner of " + op.match.Rule.Pattern + suffix
+		candidates = policy.filter(op.pool, candidates)
+		if policy.needSeniors() > 0 {
+			// Последние свободные слоты принадлежат senior-ревьюверам.
+			seniors := seniorCandidates(candidates)
+			if len(seniors) > 0 || len(res.ReviewerIDs) >= req.Count-policy.needSeniors() {
+				candidates = seniors
+			}
+		}
+		if best := bestSkillCover(candidates, uncovered); len(best) > 0 {
+			candidates = best
+		}
+		if len(candidates) == 0 {
+			s.logger.Warn("code_owner_rule_uncovered", "pr_id", req.PR.ID, "pattern", op.match.Rule.Pattern, "paths", op.match.Paths)
+			reasons = append(reasons, "no available code owner for "+op.match.Rule.Pattern)
+			continue
+		}
+
+		selection, err := run(op.pool, candidates, 1, note)
+		if err != nil {
+			return pickResult{}, err
+		}
+		reasons = append(reasons, joinReason(note, selection.Reason))
+	}
+
+	for _, pool := range teamPools {
+		if len(res.ReviewerIDs) >= req.Count {
+			break
+		}
+		pools = append(pools, pool)
+
+		if err := choose(pool, pool.underCapacity, ""); err != nil {
+			return pickResult{}, err
+		}
+	}
+
+	for _, pool := range pools {
+		if len(res.ReviewerIDs) >= req.Count {
+			break
+		}
+		if err := choose(pool, pool.offHours, "outside working hours"); err != nil {
+			return pickResult{}, err
+		}
+	}
+
+	remaining := req.Count - len(res.ReviewerIDs)
+	overloaded := 0
+	for _, pool := range pools {
+		overloaded += len(pool.atCapacity)
+	}
+
+	if remaining > 0 && overloaded > 0 {
+		switch req.Home.Settings.CapacityPolicy {
+		case domain.CapacityPolicyNoCandidate:
+			return pickResult{}, domain.NewDomainError(domain.ErrorCodeNoCandidate, "all candidates are at review capacity")
+		case domain.CapacityPolicyLeaveEmpty:
+			reasons = append(reasons, fmt.Sprintf("%d slot(s) left empty: candidates at review capacity", remaining))
+		default:
+			before := len(res.ReviewerIDs)
+			for _, pool := range pools {
+				if err := choose(pool, pool.atCapacity, "over capacity"); err != nil {
+					return pickResult{}, err
+				}
+			}
+			if over := res.ReviewerIDs[before:]; len(over) > 0 {
+				s.logger.Warn("reviewers_assigned_over_capacity",
+					"pr_id", req.PR.ID,
+					"team", req.Home.Name,
+					"reviewers", over,
+				)
+			}
+		}
+	}
+
+	if violation := policy.unsatisfied(req.Count - len(res.ReviewerIDs)); violation != "" {
+		switch req.Home.Settings.SeniorityFallback {
+		case domain.SeniorityFallbackIgnore:
+			policy.relaxed = true
+			before := len(res.ReviewerIDs)
+			for _, pool := range pools {
+				if err := choose(pool, pool.underCapacity, "seniority policy relaxed"); err != nil {
+					return pickResult{}, err
+				}
+			}
+			for _, pool := range pools {
+				if err := choose(pool, pool.offHours, "seniority policy relaxed, outside working hours"); err != nil {
+					return pickResult{}, err
+				}
+			}
+			if assignsOverCapacity(req.Home.Settings.CapacityPolicy) {
+				for _, pool := range pools {
+					if err := choose(pool, pool.atCapacity, "seniority policy relaxed, over capacity"); err != nil {
+						return pickResult{}, err
+					}
+				}
+			}
+			s.logger.Warn("seniority_policy_relaxed",
+				"pr_id", req.PR.ID,
+				"team", req.Home.Name,
+				"violation", violation,
+				"reviewers", res.ReviewerIDs[before:],
+			)
+			reasons = append(reasons, "seniority policy relaxed: "+violation)
+		case domain.SeniorityFallbackLeaveEmpty:
+			reasons = append(reasons, fmt.Sprintf("%d slot(s) left empty: %s",
+				req.Count-len(res.ReviewerIDs), violation))
+		default:
+			return pickResult{}, domain.NewDomainError(domain.ErrorCodeSeniorityUnsatisfied,
+				"seniority policy of team "+req.Home.Name+" cannot be satisfied: "+violation)
+		}
+	}
+
+	res.Reason = strings.Join(reasons, "; ")
+	res.UncoveredSkills = uncovered
+
+	ownerPools := make([]*candidatePool, 0, len(labelPools)+len(owners))
+	for _, lp := range labelPools {
+		ownerPools = append(ownerPools, lp.pool)
+	}
+	for _, op := range owners {
+		ownerPools = append(ownerPools, op.pool)
+	}
+	res.pools = append(ownerPools, pools...)
+	res.Skipped = skippedCandidates(res.pools, res.ReviewerIDs)
+	return res, nil
+}
+
+// pairScores считает PairScore кандидатов для автора: каждое назначение на его PR
+// за последние window весит от 1 (только что) до 0 (на границе окна).
+func (s *PRService) pairScores(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	authorID string,
+	candidates []domain.User,
+	window time.Duration,
+	now time.Time,
+) (map[string]float64, error) {
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.ID)
+	}
+
+	history, err := s.prs.GetPairAssignments(ctx, exec, authorID, ids, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[string]float64, len(history))
+	for id, times := range history {
+		for _, at := range times {
+			if age := now.Sub(at); age < window {
+				scores[id] += 1 - max(age, 0).Seconds()/window.Seconds()
+			}
+		}
+	}
+	return scores, nil
+}
+
+// selectionRound сохраняет вход и результат вызова стратегии для объяснения выбора.
+func selectionRound(in SelectionInput, note string, selection Selection) domain.SelectionRound {
+	round := domain.SelectionRound{
+		Note:       note,
+		Count:      in.Count,
+		Assigned:   in.Assigned,
+		Candidates: make([]domain.CandidateSnapshot, 0, len(in.Candidates)),
+		Picked:     selection.ReviewerIDs,
+	}
+	for _, c := range in.Candidates {
+		snap := domain.CandidateSnapshot{
+			UserID:      c.ID,
+			OpenReviews: in.Load[c.ID],
+			PairScore:   in.PairScore[c.ID],
+			Weight:      c.ReviewWeight,
+		}
+		if t, ok := in.LastAssignedAt[c.ID]; ok {
+			snap.LastAssignedAt = &t
+		}
+		round.Candidates = append(round.Candidates, snap)
+	}
+	return round
+}
+
+// skippedCandidates собирает причины пропуска из пулов, кроме тех, кого в итоге выбрали.
+func skippedCandidates(pools []*candidatePool, picked []string) []domain.SkippedReviewer {
+	seen := make(map[string]struct{}, len(picked))
+	for _, id := range picked {
+		seen[id] = struct{}{}
+	}
+
+	var out []domain.SkippedReviewer
+	for _, pool := range pools {
+		for _, id := range pool.skippedOrder {
+			if _, exists := seen[id]; exists {
+				continue
+			}
+			seen[id] = struct{}{}
+			out = append(out, domain.SkippedReviewer{UserID: id, Reason: pool.skipped[id]})
+		}
+	}
+	return out
+}
+
+func assignsOverCapacity(policy domain.CapacityPolicy) bool {
+	return policy != domain.CapacityPolicyNoCandidate && policy != domain.CapacityPolicyLeaveEmpty
+}
+
+func freeCandidates(candidates []domain.User, taken map[string]struct{}) []domain.User {
+	free := make([]domain.User, 0, len(candidates))
+	for _, c := range candidates {
+		if _, exists := taken[c.ID]; !exists {
+			free = append(free, c)
+		}
+	}
+	return free
+}
+
+// bestSkillCover возвращает кандидатов, покрывающих максимум навыков из skills (хотя бы один).
+func bestSkillCover(candidates []domain.User, skills []string) []domain.User {
+	best := 0
+	var res []domain.User
+	for _, c := range candidates {
+		n := 0
+		for _, skill := range skills {
+			if c.HasSkill(skill) {
+				n++
+			}
+		}
+		switch {
+		case n == 0 || n < best:
+		case n > best:
+			best = n
+			res = []domain.User{c}
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func withoutSkillsOf(skills []string, u domain.User) []string {
+	out := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		if !u.HasSkill(skill) {
+			out = append(out, skill)
+		}
+	}
+	return out
+}
+
+func joinReason(prefix, reason string) string {
+	if prefix == "" {
+		return reason
+	}
+	return prefix + ": " + reason
+}
+
+// teamPools собирает пулы домашней команды и по порядку её fallback-команд.
+func (s *PRService) teamPools(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	req pickRequest,
+	teamNames []string,
+	taken map[string]struct{},
+	now time.Time,
+) ([]*candidatePool, error) {
+	out := make([]*candidatePool, 0, len(teamNames))
+	for i, teamName := range teamNames {
+		team := req.Home
+		if i > 0 {
+			t, err := s.teams.GetTeamWithMembers(ctx, exec, teamName)
+			if err != nil {
+				if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+					s.logger.Warn("fallback_team_not_found", "team", req.Home.Name, "fallback", teamName)
+					continue
+				}
+				return nil, err
+			}
+			team = t
+		}
+
+		pool, err := s.buildCandidatePool(ctx, exec, team, i > 0, taken, req.Conflicts, now)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, pool)
+	}
+	return out, nil
+}
+
+// buildCandidatePool отбирает незанятых участников команды в новый пул.
+func (s *PRService) buildCandidatePool(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	team *domain.Team,
+	fallback bool,
+	taken map[string]struct{},
+	excluded map[string]string,
+	now time.Time,
+) (*candidatePool, error) {
+	pool := &candidatePool{team: team, fallback: fallback, excluded: excluded}
+	return pool, s.prepareCandidatePool(ctx, exec, pool, notTaken(team.Members, taken), team.Settings.ReviewCapacity, now)
+}
+
+func notTaken(users []domain.User, taken map[string]struct{}) []domain.User {
+	out := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		if _, exists := taken[u.ID]; !exists {
+			out = append(out, u)
+		}
+	}
+	return out
+}
+
+// prepareCandidatePool отбрасывает неактивных, исключённых и отсутствующих (отпуск и т.п.)
+// кандидатов; остальные остаются в pool.candidates. Причины пропуска пишутся в pool.
+func (s *PRService) prepareCandidatePool(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pool *candidatePool,
+	candidates []domain.User,
+	capacityOf func(domain.User) (int, bool),
+	now time.Time,
+) error {
+	pool.capacityOf = capacityOf
+
+	active := make([]domain.User, 0, len(candidates))
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if !c.IsActive {
+			pool.skip(c.ID, "inactive")
+			continue
+		}
+		if reason, excluded := pool.excluded[c.ID]; excluded {
+			pool.skip(c.ID, reason)
+			continue
+		}
+		active = append(active, c)
+		ids = append(ids, c.ID)
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	away, err := s.users.UnavailableAt(ctx, exec, ids, now)
+	if err != nil {
+		return err
+	}
+	for _, c := range active {
+		if period, ok := away[c.ID]; ok {
+			reason := "unavailable until " + period.EndsAt.Format(time.RFC3339)
+			if period.Reason != "" {
+				reason += ": " + period.Reason
+			}
+			pool.skip(c.ID, reason)
+			continue
+		}
+		pool.candidates = append(pool.candidates, c)
+	}
+
+	return nil
+}
+
+// lockCandidatePools одним запросом блокирует кандидатов всех пулов, чтобы параллельные
+// назначения брали блокировки в одном порядке (по user_id), затем загружает нагрузку
+// кандидатов и раскладывает их по лимиту открытых ревью и рабочему времени
+// (по политике домашней команды home). При readOnly (предпросмотр) кандидаты не блокируются.
+func (s *PRService) lockCandidatePools(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pools []*candidatePool,
+	readOnly bool,
+	home domain.TeamSettings,
+	now time.Time,
+) error {
+	var ids []string
+	for _, pool := range pools {
+		for _, c := range pool.candidates {
+			ids = append(ids, c.ID)
+		}
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	if len(ids) > 0 && !readOnly {
+		if err := s.users.LockUsers(ctx, exec, ids); err != nil {
+			return err
+		}
+	}
+
+	for _, pool := range pools {
+		if err := s.loadCandidatePool(ctx, exec, pool, home, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadCandidatePool загружает нагрузку уже заблокированных кандидатов пула и раскладывает их
+// по лимиту открытых ревью и рабочему времени. Причины пропуска пишутся в pool.
+func (s *PRService) loadCandidatePool(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	pool *candidatePool,
+	home domain.TeamSettings,
+	now time.Time,
+) error {
+	if len(pool.candidates) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(pool.candidates))
+	for _, c := range pool.candidates {
+		ids = append(ids, c.ID)
+	}
+
+	load, err := s.prs.CountOpenReviews(ctx, exec, ids)
+	if err != nil {
+		return err
+	}
+	lastAssigned, err := s.prs.GetLastAssignedAt(ctx, exec, ids)
+	if err != nil {
+		return err
+	}
+	pool.load = load
+	pool.lastAssignedAt = lastAssigned
+
+	for _, c := range pool.candidates {
+		if capacity, limited := pool.capacityOf(c); limited && load[c.ID] >= capacity {
+			pool.atCapacity = append(pool.atCapacity, c)
+			pool.skip(c.ID, fmt.Sprintf("at review capacity (%d/%d open reviews)", load[c.ID], capacity))
+			continue
+		}
+		if reason := home.OffHours(c, now); reason != "" {
+			pool.offHours = append(pool.offHours, c)
+			pool.skip(c.ID, reason)
+			continue
+		}
+		pool.underCapacity = append(pool.underCapacity, c)
+	}
+
+	return nil
+}
+
+func (s *PRService) runSelector(selector ReviewerSelector, in SelectionInput) (Selection, error) {
+	if selector == nil {
+		return Selection{}, errors.New("reviewer selector is not configured")
+	}
+
+	selection := selector.Select(in)
+
+	s.logger.Debug("reviewers_selected",
+		"pr_id", in.PR.ID,
+		"strategy", selector.Name(),
+		"reviewers", selection.ReviewerIDs,
+		"reason", selection.Reason,
+	)
+
+	return selection, nil
+}
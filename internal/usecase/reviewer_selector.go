@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	// StrategyPairRotation выбирает тех, кто давно не ревьюил этого автора.
+	StrategyPairRotation = "pair_rotation"
+	// StrategyManual — ревьювер указан явно (reassign с new_user_id); стратегия не вызывалась.
+	StrategyManual = "manual"
+	// StrategyWeighted — лотерея с весами участников (domain.User.ReviewWeight).
+	StrategyWeighted = "weighted"
+)
+
+// SelectionInput — всё, что нужно стратегии для выбора ревьюверов.
+// Candidates уже отфильтрованы PRService (активные, не автор, не назначенные).
+type SelectionInput struct {
+	PR         *domain.PullRequest
+	Author     *domain.User
+	Candidates []domain.User
+	Assigned   []string
+	Count      int
+
+	// Load — число OPEN PR, которые кандидат сейчас ревьюит.
+	Load           map[string]int
+	LastAssignedAt map[string]time.Time
+	// PairScore — насколько часто и недавно кандидат ревьюил автора (см. pairScore);
+	// заполняется только для стратегии pair_rotation.
+	PairScore map[string]float64
+
+	// Rand — генератор, созданный из seed назначения; nil — без случайности.
+	Rand Rand
+}
+
+type Selection struct {
+	ReviewerIDs []string
+	Reason      string
+}
+
+type ReviewerSelector interface {
+	Name() string
+	Select(in SelectionInput) Selection
+}
+
+func NewReviewerSelector(name string) (ReviewerSelector, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case StrategyRandom, "":
+		return &RandomSelector{}, nil
+	case StrategyRoundRobin:
+		return &RoundRobinSelector{}, nil
+	case StrategyLeastLoaded:
+		return &LeastLoadedSelector{}, nil
+	case StrategyPairRotation:
+		return &PairRotationSelector{}, nil
+	case StrategyWeighted:
+		return &WeightedSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer strategy %q", name)
+	}
+}
+
+// ReviewerSelectors хранит стратегию по умолчанию и переопределения по командам.
+type ReviewerSelectors struct {
+	def    ReviewerSelector
+	byTeam map[string]ReviewerSelector
+}
+
+func NewReviewerSelectors(defaultName string, perTeam map[string]string) (*ReviewerSelectors, error) {
+	def, err := NewReviewerSelector(defaultName)
+	if err != nil {
+		return nil, err
+	}
+
+	byTeam := make(map[string]ReviewerSelector, len(perTeam))
+	for team, name := range perTeam {
+		sel, err := NewReviewerSelector(name)
+		if err != nil {
+			return nil, fmt.Errorf("team %q: %w", team, err)
+		}
+		byTeam[team] = sel
+	}
+
+	return &ReviewerSelectors{
+		def:    def,
+		byTeam: byTeam,
+	}, nil
+}
+
+func (s *ReviewerSelectors) ForTeam(teamName string) ReviewerSelector {
+	if s == nil {
+		return &RandomSelector{}
+	}
+	if sel, ok := s.byTeam[teamName]; ok {
+		return sel
+	}
+	return s.def
+}
+
+type RandomSelector struct{}
+
+func (s *RandomSelector) Name() string {
+	return StrategyRandom
+}
+
+func (s *RandomSelector) Select(in SelectionInput) Selection {
+	var ids []string
+	switch {
+	case in.Count <= 0:
+	case in.Count == 1:
+		if id := chooseOne(in.Candidates, in.Rand); id != "" {
+			ids = []string{id}
+		}
+	default:
+		ids = chooseReviewers(in.Candidates, in.Count, in.Rand)
+	}
+	return Selection{
+		ReviewerIDs: ids,
+		Reason:      fmt.Sprintf("random choice among %d candidates", len(in.Candidates)),
+	}
+}
+
+// RoundRobinSelector выбирает тех, кого дольше всех не назначали.
+// Состояние берётся из БД (LastAssignedAt), поэтому одинаково работает на всех репликах.
+type RoundRobinSelector struct{}
+
+func (s *RoundRobinSelector) Name() string {
+	return StrategyRoundRobin
+}
+
+func (s *RoundRobinSelector) Select(in SelectionInput) Selection {
+	ordered := append([]domain.User(nil), in.Candidates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ti, okI := in.LastAssignedAt[ordered[i].ID]
+		tj, okJ := in.LastAssignedAt[ordered[j].ID]
+		if okI != okJ {
+			return !okI
+		}
+		return ti.Before(tj)
+	})
+
+	return Selection{
+		ReviewerIDs: takeIDs(ordered, in.Count),
+		Reason:      fmt.Sprintf("round robin: least recently assigned among %d candidates", len(in.Candidates)),
+	}
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом открытых ревью.
+// При равной нагрузке порядок случайный.
+type LeastLoadedSelector struct{}
+
+func (s *LeastLoadedSelector) Name() string {
+	return StrategyLeastLoaded
+}
+
+func (s *LeastLoadedSelector) Select(in SelectionInput) Selection {
+	ordered := shuffleUsers(in.Candidates, in.Rand)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return in.Load[ordered[i].ID] < in.Load[ordered[j].ID]
+	})
+
+	return Selection{
+		ReviewerIDs: takeIDs(ordered, in.Count),
+		Reason:      fmt.Sprintf("least loaded by open reviews among %d candidates", len(in.Candidates)),
+	}
+}
+
+// PairRotationSelector снижает шанс повторить недавние пары автор–ревьювер:
+// вес кандидата 1/(1+PairScore), выбор случайный пропорционально весу.
+// Без Rand выбираются кандидаты с наименьшим PairScore.
+type PairRotationSelector struct{}
+
+// pairWeightScale переводит дробные веса в целые для Rand.Intn.
+const pairWeightScale = 1000
+
+func (s *PairRotationSelector) Name() string {
+	return StrategyPairRotation
+}
+
+func (s *PairRotationSelector) Select(in SelectionInput) Selection {
+	reason := fmt.Sprintf("pair rotation: weighted by recent reviews of the author among %d candidates", len(in.Candidates))
+
+	if in.Rand == nil {
+		ordered := append([]domain.User(nil), in.Candidates...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return in.PairScore[ordered[i].ID] < in.PairScore[ordered[j].ID]
+		})
+		return Selection{ReviewerIDs: takeIDs(ordered, in.Count), Reason: reason}
+	}
+
+	weights := make([]int, len(in.Candidates))
+	for i, c := range in.Candidates {
+		weights[i] = max(1, int(pairWeightScale/(1+in.PairScore[c.ID])))
+	}
+
+	return Selection{ReviewerIDs: weightedSample(in.Candidates, weights, in.Count, in.Rand), Reason: reason}
+}
+
+// WeightedSelector — лотерея: шанс кандидата пропорционален его ReviewWeight.
+// Кандидаты с нулевым весом выбираются, только если остальных не хватило.
+// Без Rand кандидаты берутся по убыванию веса.
+type WeightedSelector struct{}
+
+func (s *WeightedSelector) Name() string {
+	return StrategyWeighted
+}
+
+func (s *WeightedSelector) Select(in SelectionInput) Selection {
+	reason := fmt.Sprintf("weighted lottery by review weight among %d candidates", len(in.Candidates))
+
+	if in.Rand == nil {
+		ordered := append([]domain.User(nil), in.Candidates...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].ReviewWeight > ordered[j].ReviewWeight
+		})
+		return Selection{ReviewerIDs: takeIDs(ordered, in.Count), Reason: reason}
+	}
+
+	var weighted, zero []domain.User
+	weights := make([]int, 0, len(in.Candidates))
+	for _, c := range in.Candidates {
+		if c.ReviewWeight <= 0 {
+			zero = append(zero, c)
+			continue
+		}
+		weighted = append(weighted, c)
+		weights = append(weights, c.ReviewWeight)
+	}
+
+	ids := weightedSample(weighted, weights, in.Count, in.Rand)
+	ids = append(ids, takeIDs(zero, in.Count-len(ids))...)
+	return Selection{ReviewerIDs: ids, Reason: reason}
+}
+
+// weightedSample выбирает до count кандидатов без повторов; шанс пропорционален
+// весу (weights — положительные, в порядке candidates).
+func weightedSample(candidates []domain.User, weights []int, count int, r Rand) []string {
+	pool := append([]domain.User(nil), candidates...)
+	weights = append([]int(nil), weights...)
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+
+	var ids []string
+	for len(ids) < count && len(pool) > 0 {
+		n := r.Intn(total)
+		i := 0
+		for n >= weights[i] {
+			n -= weights[i]
+			i++
+		}
+		ids = append(ids, pool[i].ID)
+		total -= weights[i]
+		pool = append(pool[:i], pool[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+	return ids
+}
+
+// shuffleUsers возвращает перемешанную копию; без Rand порядок сохраняется.
+func shuffleUsers(users []domain.User, r Rand) []domain.User {
+	out := append([]domain.User(nil), users...)
+	if r == nil {
+		return out
+	}
+	for i := len(out) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+func takeIDs(users []domain.User, n int) []string {
+	if n > len(users) {
+		n = len(users)
+	}
+	if n <= 0 {
+		return nil
+	}
+	ids := make([]string, 0, n)
+	for _, u := range users[:n] {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+// chooseReviewers выбирает до n различных кандидатов (частичная тасовка Фишера–Йетса).
+func chooseReviewers(candidates []domain.User, n int, r Rand) []string {
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	if n <= 0 {
+		return nil
+	}
+	if r == nil {
+		return takeIDs(candidates, n)
+	}
+
+	pool := append([]domain.User(nil), candidates...)
+	for k := 0; k < n; k++ {
+		j := k + r.Intn(len(pool)-k)
+		pool[k], pool[j] = pool[j], pool[k]
+	}
+
+	return takeIDs(pool, n)
+}
+
+func chooseOne(candidates []domain.User, r Rand) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	if r == nil {
+		return candidates[0].ID
+	}
+	idx := r.Intn(len(candidates))
+	return candidates[idx].ID
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+func TestNewReviewerSelector_Unknown(t *testing.T) {
+	if _, err := NewReviewerSelector("nope"); err == nil {
+		t.Fatalf("expected error for unknown strategy")
+	}
+}
+
+func TestReviewerSelectors_ForTeam(t *testing.T) {
+	sels, err := NewReviewerSelectors(StrategyRandom, map[string]string{"backend": StrategyLeastLoaded})
+	if err != nil {
+		t.Fatalf("NewReviewerSelectors() error = %v", err)
+	}
+
+	if got := sels.ForTeam("backend").Name(); got != StrategyLeastLoaded {
+		t.Fatalf("backend strategy = %s, want %s", got, StrategyLeastLoaded)
+	}
+	if got := sels.ForTeam("frontend").Name(); got != StrategyRandom {
+		t.Fatalf("frontend strategy = %s, want %s", got, StrategyRandom)
+	}
+}
+
+func TestRandomSelector_RespectsCount(t *testing.T) {
+	candidates := []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+	sel := &RandomSelector{}
+
+	res := sel.Select(SelectionInput{Candidates: candidates, Count: 1, Rand: &fakeRand{seq: []int{2}}})
+	if len(res.ReviewerIDs) != 1 || res.ReviewerIDs[0] != "u3" {
+		t.Fatalf("expected [u3], got %#v", res.ReviewerIDs)
+	}
+	if res.Reason == "" {
+		t.Fatalf("expected non-empty reason")
+	}
+}
+
+func TestRoundRobinSelector_PrefersNeverAssignedThenOldest(t *testing.T) {
+	now := time.Now()
+	candidates := []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+
+	res := (&RoundRobinSelector{}).Select(SelectionInput{
+		Candidates: candidates,
+		Count:      2,
+		LastAssignedAt: map[string]time.Time{
+			"u1": now,
+			"u3": now.Add(-time.Hour),
+		},
+	})
+
+	if len(res.ReviewerIDs) != 2 || res.ReviewerIDs[0] != "u2" || res.ReviewerIDs[1] != "u3" {
+		t.Fatalf("expected [u2 u3], got %#v", res.ReviewerIDs)
+	}
+}
+
+func TestLeastLoadedSelector_PicksLowestLoad(t *testing.T) {
+	candidates := []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+
+	res := (&LeastLoadedSelector{}).Select(SelectionInput{
+		Candidates: candidates,
+		Count:      2,
+		Load:       map[string]int{"u1": 5, "u2": 1},
+	})
+
+	if len(res.ReviewerIDs) != 2 || res.ReviewerIDs[0] != "u3" || res.ReviewerIDs[1] != "u2" {
+		t.Fatalf("expected [u3 u2], got %#v", res.ReviewerIDs)
+	}
+}
+
+func TestLeastLoadedSelector_BreaksTiesRandomly(t *testing.T) {
+	candidates := []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+	in := SelectionInput{
+		Candidates: candidates,
+		Count:      1,
+		Load:       map[string]int{"u1": 0, "u2": 0, "u3": 4},
+	}
+
+	seen := map[string]bool{}
+	for _, seq := range [][]int{{0, 0}, {1, 1}, {2, 0}} {
+		in.Rand = &fakeRand{seq: seq}
+		res := (&LeastLoadedSelector{}).Select(in)
+		if len(res.ReviewerIDs) != 1 {
+			t.Fatalf("expected 1 reviewer, got %#v", res.ReviewerIDs)
+		}
+		if res.ReviewerIDs[0] == "u3" {
+			t.Fatalf("most loaded candidate must not be chosen")
+		}
+		seen[res.ReviewerIDs[0]] = true
+	}
+
+	if !seen["u1"] || !seen["u2"] {
+		t.Fatalf("expected both tied candidates to be reachable, got %v", seen)
+	}
+}
+
+func TestPairRotationSelector_PrefersFreshPairs(t *testing.T) {
+	candidates := []domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+	scores := map[string]float64{"u1": 2.5, "u2": 0.4}
+
+	res := (&PairRotationSelector{}).Select(SelectionInput{Candidates: candidates, Count: 2, PairScore: scores})
+	if len(res.ReviewerIDs) != 2 || res.ReviewerIDs[0] != "u3" || res.ReviewerIDs[1] != "u2" {
+		t.Fatalf("expected [u3 u2], got %#v", res.ReviewerIDs)
+	}
+
+	// веса: u1 = 285, u2 = 714, u3 = 1000; 500 попадает в u2, затем 0 — в u1
+	res = (&PairRotationSelector{}).Select(SelectionInput{
+		Candidates: candidates,
+		Count:      2,
+		PairScore:  scores,
+		Rand:       &fakeRand{seq: []int{500, 0}},
+	})
+	if len(res.ReviewerIDs) != 2 || res.ReviewerIDs[0] != "u2" || res.ReviewerIDs[1] != "u1" {
+		t.Fatalf("expected [u2 u1], got %#v", res.ReviewerIDs)
+	}
+}
+
+func TestWeightedSelector_DrawsByWeight(t *testing.T) {
+	candidates := []domain.User{
+		{ID: "u1", ReviewWeight: 100},
+		{ID: "u2", ReviewWeight: 0},
+		{ID: "u3", ReviewWeight: 25},
+	}
+
+	res := (&WeightedSelector{}).Select(SelectionInput{Candidates: candidates, Count: 2})
+	if len(res.ReviewerIDs) != 2 || res.ReviewerIDs[0] != "u1" || res.ReviewerIDs[1] != "u3" {
+		t.Fatalf("expected [u1 u3], got %#v", res.ReviewerIDs)
+	}
+
+	// веса: u1 = 100, u3 = 25; 110 попадает в u3, затем остаётся только u1
+	res = (&WeightedSelector{}).Select(SelectionInput{
+		Candidates: candidates,
+		Count:      2,
+		Rand:       &fakeRand{seq: []int{110, 0}},
+	})
+	if len(res.ReviewerIDs) != 2 || res.ReviewerIDs[0] != "u3" || res.ReviewerIDs[1] != "u1" {
+		t.Fatalf("expected [u3 u1], got %#v", res.ReviewerIDs)
+	}
+
+	// нулевой вес — только когда остальных не хватает
+	res = (&WeightedSelector{}).Select(SelectionInput{
+		Candidates: candidates,
+		Count:      3,
+		Rand:       &fakeRand{seq: []int{0}},
+	})
+	if len(res.ReviewerIDs) != 3 || res.ReviewerIDs[2] != "u2" {
+		t.Fatalf("expected u2 last, got %#v", res.ReviewerIDs)
+	}
+}
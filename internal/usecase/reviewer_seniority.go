@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// seniorityPolicy отслеживает состав ревьюверов PR относительно политики команды:
+// сколько ещё нужно senior-ревьюверов и можно ли добавить очередного junior.
+type seniorityPolicy struct {
+	minSeniors int
+	maxJuniors *int
+
+	seniors int
+	juniors int
+	// blocked — хотя бы один junior был отброшен из-за лимита.
+	blocked bool
+	// relaxed — политика отключена (SeniorityFallbackIgnore).
+	relaxed bool
+}
+
+// seniorityPolicyFor считает уже назначенных ревьюверов (кроме исключённых) в состав PR.
+func (s *PRService) seniorityPolicyFor(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	req pickRequest,
+) (*seniorityPolicy, error) {
+	settings := req.Home.Settings
+	policy := &seniorityPolicy{minSeniors: settings.MinSeniorReviewers, maxJuniors: settings.MaxJuniorReviewers}
+	if !policy.enabled() {
+		return policy, nil
+	}
+
+	for _, id := range req.Assigned {
+		if slices.Contains(req.Exclude, id) {
+			continue
+		}
+		u, err := s.users.GetUserByID(ctx, exec, id)
+		if err != nil {
+			return nil, err
+		}
+		policy.add(*u)
+	}
+	return policy, nil
+}
+
+func (p *seniorityPolicy) enabled() bool {
+	return p.minSeniors > 0 || p.maxJuniors != nil
+}
+
+// limitsJuniors — действует ли лимит junior-ревьюверов; тогда выбор идёт по одному.
+func (p *seniorityPolicy) limitsJuniors() bool {
+	return !p.relaxed && p.maxJuniors != nil
+}
+
+func (p *seniorityPolicy) needSeniors() int {
+	if p.relaxed {
+		return 0
+	}
+	return max(p.minSeniors-p.seniors, 0)
+}
+
+func (p *seniorityPolicy) allows(u domain.User) bool {
+	return !p.limitsJuniors() || !u.Seniority.IsJunior() || p.juniors < *p.maxJuniors
+}
+
+func (p *seniorityPolicy) add(u domain.User) {
+	switch {
+	case u.Seniority.IsSenior():
+		p.seniors++
+	case u.Seniority.IsJunior():
+		p.juniors++
+	}
+}
+
+// filter убирает junior-кандидатов сверх лимита и записывает причину в пул.
+func (p *seniorityPolicy) filter(pool *candidatePool, candidates []domain.User) []domain.User {
+	out := make([]domain.User, 0, len(candidates))
+	for _, c := range candidates {
+		if !p.allows(c) {
+			p.blocked = true
+			if _, exists := pool.skipped[c.ID]; !exists {
+				pool.skip(c.ID, fmt.Sprintf("junior reviewer limit reached (%d)", *p.maxJuniors))
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// unsatisfied описывает нарушение политики; пустая строка — политика соблюдена.
+// Лимит junior считается нарушением, только если из-за него остались пустые слоты.
+func (p *seniorityPolicy) unsatisfied(remaining int) string {
+	if n := p.needSeniors(); n > 0 {
+		return fmt.Sprintf("%d more senior reviewer(s) required", n)
+	}
+	if remaining > 0 && p.blocked && p.limitsJuniors() {
+		return fmt.Sprintf("at most %d junior reviewer(s) allowed", *p.maxJuniors)
+	}
+	return ""
+}
+
+// admit добавляет в состав выбранного вручную ревьювера и возвращает нарушение политики, если оно есть.
+func (p *seniorityPolicy) admit(u domain.User) string {
+	if !p.allows(u) {
+		return fmt.Sprintf("at most %d junior reviewer(s) allowed", *p.maxJuniors)
+	}
+	p.add(u)
+	if n := p.needSeniors(); n > 0 {
+		return fmt.Sprintf("%d more senior reviewer(s) required", n)
+	}
+	return ""
+}
+
+func seniorCandidates(candidates []domain.User) []domain.User {
+	out := make([]domain.User, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Seniority.IsSenior() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
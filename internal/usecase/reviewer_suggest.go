@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// SuggestedCandidate — кандидат в ревьюверы и его место в очереди выбора.
+type SuggestedCandidate struct {
+	UserID      string
+	TeamName    string
+	Rank        int
+	OpenReviews int
+	Selected    bool
+	// Note — почему кандидат рассматривается в последнюю очередь (вне рабочего времени,
+	// лимит открытых ревью); пусто для обычных кандидатов.
+	Note string
+}
+
+// ReviewerSuggestion — результат предпросмотра: PR с ревьюверами, которые были бы
+// назначены, и ранжированный список кандидатов.
+type ReviewerSuggestion struct {
+	PR         *domain.PullRequest
+	Candidates []SuggestedCandidate
+	// Seed — seed выбора; переданный в CreatePRInput.Seed, он даёт тот же выбор при создании.
+	Seed int64
+}
+
+// SuggestReviewers выбирает ревьюверов так же, как CreatePRWithAutoAssign, но ничего
+// не записывает и не блокирует кандидатов. Если in.Seed не задан, берётся новый seed.
+func (s *PRService) SuggestReviewers(
+	ctx context.Context,
+	in CreatePRInput,
+) (*ReviewerSuggestion, error) {
+	var res *ReviewerSuggestion
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		if _, _, err := s.prs.GetPRByID(ctx, exec, in.ID); err == nil {
+			return domain.NewDomainError(domain.ErrorCodePRExists, "pr id already exists")
+		} else if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodeNotFound {
+			return err
+		}
+
+		pr, _, picked, err := s.planCreate(ctx, exec, in, true)
+		if err != nil {
+			return err
+		}
+
+		res = &ReviewerSuggestion{
+			PR:         pr,
+			Candidates: rankCandidates(picked.pools, picked.ReviewerIDs),
+			Seed:       picked.Seed,
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("pr_suggest_reviewers_failed", "pr_id", in.ID, "err", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// rankCandidates упорядочивает кандидатов так, как их рассматривает pickReviewers:
+// выбранные в порядке выбора, затем доступные, вне рабочего времени и достигшие лимита;
+// внутри группы — по числу открытых ревью.
+func rankCandidates(pools []*candidatePool, picked []string) []SuggestedCandidate {
+	type tiered struct {
+		SuggestedCandidate
+		tier int
+	}
+
+	selected := make(map[string]int, len(picked))
+	for i, id := range picked {
+		selected[id] = i
+	}
+
+	seen := make(map[string]struct{})
+	var all []tiered
+	for _, pool := range pools {
+		for tier, users := range [][]domain.User{pool.underCapacity, pool.offHours, pool.atCapacity} {
+			for _, u := range users {
+				if _, exists := seen[u.ID]; exists {
+					continue
+				}
+				seen[u.ID] = struct{}{}
+
+				c := tiered{
+					SuggestedCandidate: SuggestedCandidate{
+						UserID:      u.ID,
+						TeamName:    u.TeamName,
+						OpenReviews: pool.load[u.ID],
+						Note:        pool.skipped[u.ID],
+					},
+					tier: tier + 1,
+				}
+				if i, ok := selected[u.ID]; ok {
+					c.Selected = true
+					c.tier = 0
+					c.Rank = i
+				}
+				all = append(all, c)
+			}
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].tier != all[j].tier {
+			return all[i].tier < all[j].tier
+		}
+		if all[i].Selected {
+			return all[i].Rank < all[j].Rank
+		}
+		return all[i].OpenReviews < all[j].OpenReviews
+	})
+
+	out := make([]SuggestedCandidate, 0, len(all))
+	for i, c := range all {
+		c.Rank = i + 1
+		out = append(out, c.SuggestedCandidate)
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+)
+
+func TestSuggestReviewers_MatchesCreateWithoutWrites(t *testing.T) {
+	two := 2
+
+	store := newFakeStore()
+	settings := domain.DefaultTeamSettings()
+	settings.DefaultReviewCapacity = &two
+	store.addTeam("backend", settings,
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		domain.User{ID: "u5", IsActive: false},
+	)
+	store.prs["busy-1"] = &domain.PullRequest{ID: "busy-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u4"}}
+	store.prs["busy-2"] = &domain.PullRequest{ID: "busy-2", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u4"}}
+
+	svc := newTestPRService(store, StrategyLeastLoaded)
+	ctx := context.Background()
+	in := CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}
+
+	suggestion, err := svc.SuggestReviewers(ctx, in)
+	if err != nil {
+		t.Fatalf("SuggestReviewers() error = %v", err)
+	}
+	if _, exists := store.prs["pr-1"]; exists || len(store.explained) != 0 {
+		t.Fatalf("suggestion must not write anything")
+	}
+
+	if want := []string{"u3", "u2"}; !slices.Equal(suggestion.PR.AssignedReviewers, want) {
+		t.Fatalf("suggested reviewers = %v, want %v", suggestion.PR.AssignedReviewers, want)
+	}
+
+	var ranked []string
+	for _, c := range suggestion.Candidates {
+		ranked = append(ranked, c.UserID)
+	}
+	if want := []string{"u3", "u2", "u4"}; !slices.Equal(ranked, want) {
+		t.Fatalf("ranked candidates = %v, want %v", ranked, want)
+	}
+	last := suggestion.Candidates[2]
+	if last.Selected || last.Rank != 3 || last.OpenReviews != 2 || last.Note == "" {
+		t.Fatalf("unexpected candidate at capacity: %+v", last)
+	}
+
+	pr, err := svc.CreatePRWithAutoAssign(ctx, in)
+	if err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	if !slices.Equal(pr.AssignedReviewers, suggestion.PR.AssignedReviewers) {
+		t.Fatalf("created reviewers = %v, suggested %v", pr.AssignedReviewers, suggestion.PR.AssignedReviewers)
+	}
+
+	_, err = svc.SuggestReviewers(ctx, in)
+	if de, ok := domain.AsDomainError(err); !ok || de.Code != domain.ErrorCodePRExists {
+		t.Fatalf("expected PR_EXISTS, got %v", err)
+	}
+}
+
+func TestSuggestReviewers_SeedReproducedOnCreate(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+		domain.User{ID: "u5", IsActive: true},
+		domain.User{ID: "u6", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	svc.rand = newRand
+	next := int64(0)
+	svc.seed = func() int64 {
+		next++
+		return next
+	}
+	ctx := context.Background()
+
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		in := CreatePRInput{ID: id, Name: "Add feature", AuthorID: "u1"}
+
+		suggestion, err := svc.SuggestReviewers(ctx, in)
+		if err != nil {
+			t.Fatalf("SuggestReviewers(%s) error = %v", id, err)
+		}
+		if len(store.locks) != 0 {
+			t.Fatalf("suggestion must not lock candidates, locks = %v", store.locks)
+		}
+
+		in.Seed = &suggestion.Seed
+		pr, err := svc.CreatePRWithAutoAssign(ctx, in)
+		if err != nil {
+			t.Fatalf("CreatePRWithAutoAssign(%s) error = %v", id, err)
+		}
+		if !slices.Equal(pr.AssignedReviewers, suggestion.PR.AssignedReviewers) {
+			t.Fatalf("%s: created reviewers = %v, suggested %v", id, pr.AssignedReviewers, suggestion.PR.AssignedReviewers)
+		}
+		if len(store.locks) == 0 {
+			t.Fatalf("%s: create must lock candidates", id)
+		}
+		store.locks = nil
+		// Следующий PR не должен зависеть от нагрузки предыдущих.
+		delete(store.prs, id)
+	}
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+// staleReviewLockKey — ключ advisory-блокировки, чтобы обработчик работал в одной реплике.
+const staleReviewLockKey int64 = 0x5354414c45
+
+// StaleReviewConfig — настройки фонового переназначения зависших ревью.
+type StaleReviewConfig struct {
+	// Interval — период проверки; 0 — обработчик выключен.
+	Interval time.Duration
+	// DryRun — только писать в лог, что было бы переназначено.
+	DryRun bool
+}
+
+// StaleReviewRun — итог одного прохода.
+type StaleReviewRun struct {
+	// Locked — блокировку держит другая реплика, проход пропущен.
+	Locked     bool
+	Found      int
+	Reassigned int
+	Failed     int
+}
+
+// StaleReviewWorker периодически переназначает ревью, которые висят дольше
+// stale_review_hours команды автора или назначены на неактивных пользователей.
+type StaleReviewWorker struct {
+	prs    *PRService
+	locks  repository.LockRepository
+	tx     TxManager
+	cfg    StaleReviewConfig
+	logger log.Logger
+}
+
+func NewStaleReviewWorker(
+	prs *PRService,
+	locks repository.LockRepository,
+	tx TxManager,
+	cfg StaleReviewConfig,
+	logger log.Logger,
+) *StaleReviewWorker {
+	return &StaleReviewWorker{
+		prs:    prs,
+		locks:  locks,
+		tx:     tx,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Run выполняет проходы раз в cfg.Interval до отмены ctx.
+func (w *StaleReviewWorker) Run(ctx context.Context) {
+	if w.cfg.Interval <= 0 {
+		w.logger.Info("stale_review_worker_disabled")
+		return
+	}
+	w.logger.Info("stale_review_worker_start", "interval", w.cfg.Interval.String(), "dry_run", w.cfg.DryRun)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stale_review_worker_stopped")
+			return
+		case <-ticker.C:
+			run, err := w.RunOnce(ctx)
+			if err != nil {
+				w.logger.Error("stale_review_run_failed", "err", err)
+				continue
+			}
+			if run.Found > 0 {
+				w.logger.Info("stale_review_run_done",
+					"found", run.Found, "reassigned", run.Reassigned, "failed", run.Failed, "dry_run", w.cfg.DryRun)
+			}
+		}
+	}
+}
+
+// RunOnce находит зависшие назначения и переназначает их так же, как ReassignReviewer.
+// Сессионная блокировка держится на отдельном соединении вне транзакции; каждое
+// переназначение выполняется в своей транзакции, и ошибка одного не мешает остальным.
+func (w *StaleReviewWorker) RunOnce(ctx context.Context) (StaleReviewRun, error) {
+	var run StaleReviewRun
+
+	err := w.tx.WithConn(ctx, func(ctx context.Context, conn repository.DBExecutor) (retErr error) {
+		locked, err := w.locks.TryLock(ctx, conn, staleReviewLockKey)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			run.Locked = true
+			w.logger.Debug("stale_review_lock_busy")
+			return nil
+		}
+		defer func() {
+			if err := w.locks.Unlock(context.WithoutCancel(ctx), conn, staleReviewLockKey); err != nil && retErr == nil {
+				retErr = err
+			}
+		}()
+
+		stale, err := w.prs.ListStaleReviews(ctx, conn)
+		if err != nil {
+			return err
+		}
+		run.Found = len(stale)
+
+		for _, st := range stale {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if w.cfg.DryRun {
+				w.logger.Info("stale_review_dry_run",
+					"pr_id", st.PRID, "reviewer", st.ReviewerID, "reason", string(st.Reason), "assigned_at", st.AssignedAt)
+				continue
+			}
+
+			_, newID, err := w.prs.ReassignReviewer(ctx, st.PRID, st.ReviewerID)
+			if err != nil {
+				run.Failed++
+				w.logger.Warn("stale_review_reassign_failed",
+					"pr_id", st.PRID, "reviewer", st.ReviewerID, "reason", string(st.Reason), "err", err)
+				continue
+			}
+			run.Reassigned++
+			w.logger.Info("stale_review_reassigned",
+				"pr_id", st.PRID, "old_reviewer", st.ReviewerID, "new_reviewer", newID, "reason", string(st.Reason))
+		}
+		return nil
+	})
+	if err != nil {
+		return StaleReviewRun{}, err
+	}
+	return run, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+func TestStaleReviewWorker_RunOnce(t *testing.T) {
+	store := newFakeStore()
+	store.addTeam("backend", domain.DefaultTeamSettings(),
+		domain.User{ID: "u1", IsActive: true},
+		domain.User{ID: "u2", IsActive: true},
+		domain.User{ID: "u3", IsActive: true},
+		domain.User{ID: "u4", IsActive: true},
+	)
+
+	svc := newTestPRService(store, StrategyRandom)
+	ctx := context.Background()
+
+	if _, err := svc.CreatePRWithAutoAssign(ctx, CreatePRInput{ID: "pr-1", Name: "Add feature", AuthorID: "u1"}); err != nil {
+		t.Fatalf("CreatePRWithAutoAssign() error = %v", err)
+	}
+	store.stale = []repository.StaleReview{
+		{PRID: "pr-1", ReviewerID: "u2", Reason: repository.StaleReviewReasonAge},
+	}
+
+	locks := &fakeLockRepo{busy: true}
+	logger := log.FromContext(ctx)
+	newWorker := func(dryRun bool) *StaleReviewWorker {
+		return NewStaleReviewWorker(svc, locks, fakeTx{}, StaleReviewConfig{DryRun: dryRun}, logger)
+	}
+
+	run, err := newWorker(false).RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if !run.Locked || run.Found != 0 {
+		t.Fatalf("expected run to be skipped while lock is busy, got %+v", run)
+	}
+
+	locks.busy = false
+	run, err = newWorker(true).RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if run.Found != 1 || run.Reassigned != 0 {
+		t.Fatalf("expected dry run to only find u2, got %+v", run)
+	}
+	if !slices.Contains(store.prs["pr-1"].AssignedReviewers, "u2") {
+		t.Fatalf("dry run must not reassign, got %#v", store.prs["pr-1"].AssignedReviewers)
+	}
+
+	run, err = newWorker(false).RunOnce(ctx)
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if run.Found != 1 || run.Reassigned != 1 || run.Failed != 0 {
+		t.Fatalf("expected u2 to be reassigned, got %+v", run)
+	}
+	if locks.held {
+		t.Fatalf("expected lock to be released after the run")
+	}
+	if got := store.prs["pr-1"].AssignedReviewers; slices.Contains(got, "u2") || len(got) != 2 {
+		t.Fatalf("expected u2 replaced, got %#v", got)
+	}
+	if len(store.explained) != 2 || store.explained[1].Kind != domain.AssignmentKindReassign {
+		t.Fatalf("expected reassign to be explained like a manual one, got %#v", store.explained)
+	}
+}
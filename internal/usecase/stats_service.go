@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
 	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
 	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
 )
@@ -23,14 +24,56 @@ func NewStatsService(
 	}
 }
 
-func (s *StatsService) GetAssignmentsByUser(
+// AssignmentShare сравнивает долю назначений пользователя внутри команды с ожидаемой по весам.
+type AssignmentShare struct {
+	UserID   string
+	TeamName string
+	Weight   int
+	Count    int
+	// Share — доля назначений команды, доставшаяся пользователю.
+	Share float64
+	// ExpectedShare — вес пользователя к сумме весов активных участников команды;
+	// для неактивных 0.
+	ExpectedShare float64
+}
+
+// GetAssignmentShares возвращает доли по всем пользователям, упорядоченные по команде и user_id.
+// Непустой label учитывает только назначения на PR с этой меткой.
+func (s *StatsService) GetAssignmentShares(
 	ctx context.Context,
 	exec repository.DBExecutor,
-) (map[string]int, error) {
-	stats, err := s.prs.GetAssignStats(ctx, exec)
+	label string,
+) ([]AssignmentShare, error) {
+	stats, err := s.prs.GetUserAssignStats(ctx, exec, domain.NormalizeLabel(label))
 	if err != nil {
-		s.logger.Error("stats_get_assignments_failed", "err", err)
-		return nil, fmt.Errorf("get assignment stats: %w", err)
+		s.logger.Error("stats_get_assignment_shares_failed", "err", err)
+		return nil, fmt.Errorf("get assignment shares: %w", err)
+	}
+
+	totalCount := make(map[string]int)
+	totalWeight := make(map[string]int)
+	for _, st := range stats {
+		totalCount[st.TeamName] += st.Count
+		if st.IsActive {
+			totalWeight[st.TeamName] += st.Weight
+		}
+	}
+
+	out := make([]AssignmentShare, 0, len(stats))
+	for _, st := range stats {
+		share := AssignmentShare{
+			UserID:   st.UserID,
+			TeamName: st.TeamName,
+			Weight:   st.Weight,
+			Count:    st.Count,
+		}
+		if n := totalCount[st.TeamName]; n > 0 {
+			share.Share = float64(st.Count) / float64(n)
+		}
+		if w := totalWeight[st.TeamName]; w > 0 && st.IsActive {
+			share.ExpectedShare = float64(st.Weight) / float64(w)
+		}
+		out = append(out, share)
 	}
-	return stats, nil
+	return out, nil
 }
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
+	"github.com/Shyyw1e/avito-trainee-fall/internal/repository"
+)
+
+type fakeStatsRepo struct {
+	repository.PRRepository
+	stats []repository.UserAssignStat
+	label string
+}
+
+func (r *fakeStatsRepo) GetUserAssignStats(_ context.Context, _ repository.DBExecutor, label string) ([]repository.UserAssignStat, error) {
+	r.label = label
+	return r.stats, nil
+}
+
+func TestGetAssignmentShares_ComparesWithWeights(t *testing.T) {
+	repo := &fakeStatsRepo{stats: []repository.UserAssignStat{
+		{UserID: "a1", TeamName: "backend", IsActive: true, Weight: 100, Count: 6},
+		{UserID: "a2", TeamName: "backend", IsActive: true, Weight: 50, Count: 4},
+		{UserID: "a3", TeamName: "backend", IsActive: false, Weight: 100, Count: 0},
+		{UserID: "f1", TeamName: "frontend", IsActive: true, Weight: 0, Count: 0},
+	}}
+	svc := NewStatsService(repo, log.FromContext(context.Background()))
+
+	shares, err := svc.GetAssignmentShares(context.Background(), nil, "")
+	if err != nil {
+		t.Fatalf("GetAssignmentShares() error = %v", err)
+	}
+	if len(shares) != 4 {
+		t.Fatalf("expected 4 shares, got %d", len(shares))
+	}
+
+	want := []struct {
+		share, expected float64
+	}{
+		{0.6, 2.0 / 3},
+		{0.4, 1.0 / 3},
+		{0, 0},
+		{0, 0},
+	}
+	for i, w := range want {
+		got := shares[i]
+		if math.Abs(got.Share-w.share) > 1e-9 || math.Abs(got.ExpectedShare-w.expected) > 1e-9 {
+			t.Fatalf("%s: share=%v expected=%v, want %v/%v", got.UserID, got.Share, got.ExpectedShare, w.share, w.expected)
+		}
+	}
+
+	if _, err := svc.GetAssignmentShares(context.Background(), nil, " Security "); err != nil || repo.label != "security" {
+		t.Fatalf("expected normalized label filter, got %q (err %v)", repo.label, err)
+	}
+}
@@ -34,13 +34,22 @@ func (s *TeamService) AddTeam(
 	ctx context.Context,
 	teamName string,
 	members []domain.User,
+	settings domain.TeamSettings,
 ) (*domain.Team, error) {
 	team, err := domain.NewTeam(teamName, members)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := team.SetSettings(settings); err != nil {
+		return nil, err
+	}
+
 	err = s.Tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		if err := s.ensureSettingsTeamsExist(ctx, exec, team.Settings); err != nil {
+			return err
+		}
+
 		if err := s.Teams.CreateTeam(ctx, exec, team); err != nil {
 			return err
 		}
@@ -92,3 +101,79 @@ func (s *TeamService) MassDeactivateTeam(
 
 	return affected, nil
 }
+
+// UpdateSettings читает текущие настройки команды, применяет к ним apply и сохраняет результат.
+func (s *TeamService) UpdateSettings(
+	ctx context.Context,
+	teamName string,
+	apply func(settings *domain.TeamSettings),
+) (*domain.Team, error) {
+	var updated *domain.Team
+
+	err := s.Tx.WithTx(ctx, func(ctx context.Context, exec repository.DBExecutor) error {
+		team, err := s.Teams.GetTeamWithMembers(ctx, exec, teamName)
+		if err != nil {
+			return err
+		}
+
+		settings := team.Settings
+		settings.FallbackTeams = append([]string(nil), settings.FallbackTeams...)
+		settings.LabelRules = append([]domain.LabelRule(nil), settings.LabelRules...)
+		apply(&settings)
+		if err := team.SetSettings(settings); err != nil {
+			return err
+		}
+
+		if err := s.ensureSettingsTeamsExist(ctx, exec, team.Settings); err != nil {
+			return err
+		}
+
+		if err := s.Teams.UpdateTeamSettings(ctx, exec, teamName, team.Settings); err != nil {
+			return err
+		}
+
+		updated = team
+		return nil
+	})
+	if err != nil {
+		s.Logger.Error("team_update_settings_failed", "team", teamName, "err", err)
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ensureSettingsTeamsExist проверяет, что существуют fallback-команды и команды из правил меток.
+func (s *TeamService) ensureSettingsTeamsExist(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	settings domain.TeamSettings,
+) error {
+	if err := s.ensureTeamsExist(ctx, exec, "fallback", settings.FallbackTeams); err != nil {
+		return err
+	}
+	var labelTeams []string
+	for _, r := range settings.LabelRules {
+		if r.RequireTeam != "" {
+			labelTeams = append(labelTeams, r.RequireTeam)
+		}
+	}
+	return s.ensureTeamsExist(ctx, exec, "label rule", labelTeams)
+}
+
+func (s *TeamService) ensureTeamsExist(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	kind string,
+	teamNames []string,
+) error {
+	for _, name := range teamNames {
+		if _, err := s.Teams.GetTeamWithMembers(ctx, exec, name); err != nil {
+			if de, ok := domain.AsDomainError(err); ok && de.Code == domain.ErrorCodeNotFound {
+				return domain.NewDomainError(domain.ErrorCodeNotFound, fmt.Sprintf("%s team %q not found", kind, name))
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -13,6 +13,8 @@ import (
 
 type TxManager interface {
 	WithTx(ctx context.Context, fn func(ctx context.Context, exec repository.DBExecutor) error) error
+	// WithConn выполняет fn на отдельном соединении вне транзакции (для сессионных блокировок).
+	WithConn(ctx context.Context, fn func(ctx context.Context, exec repository.DBExecutor) error) error
 }
 
 type PgxTxManager struct {
@@ -32,3 +34,9 @@ func (m *PgxTxManager) WithTx(ctx context.Context, fn func(ctx context.Context,
 		return fn(ctx, tx)
 	})
 }
+
+func (m *PgxTxManager) WithConn(ctx context.Context, fn func(ctx context.Context, exec repository.DBExecutor) error) error {
+	return db.WithConn(ctx, m.Pool, m.Logger, func(ctx context.Context, conn *pgxpool.Conn) error {
+		return fn(ctx, conn)
+	})
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Shyyw1e/avito-trainee-fall/internal/domain"
 	"github.com/Shyyw1e/avito-trainee-fall/internal/platform/log"
@@ -12,21 +13,38 @@ import (
 type UserService struct {
 	Users  repository.UserRepository
 	PRs    repository.PRRepository
+	Teams  repository.TeamRepository
 	Logger log.Logger
 }
 
 func NewUserService(
 	users repository.UserRepository,
 	prs repository.PRRepository,
+	teams repository.TeamRepository,
 	logger log.Logger,
 ) *UserService {
 	return &UserService{
 		Users:  users,
 		PRs:    prs,
+		Teams:  teams,
 		Logger: logger,
 	}
 }
 
+// UserLoad — текущая нагрузка ревьювера относительно его лимита.
+type UserLoad struct {
+	User        *domain.User
+	OpenReviews int
+	// Capacity — действующий лимит; nil, если лимита нет.
+	Capacity *int
+	// CapacitySource — откуда взят лимит: "user", "team" или пусто.
+	CapacitySource string
+}
+
+func (l UserLoad) AtCapacity() bool {
+	return l.Capacity != nil && l.OpenReviews >= *l.Capacity
+}
+
 func (s *UserService) SetUserIsActive(
 	ctx context.Context,
 	exec repository.DBExecutor,
@@ -41,12 +59,13 @@ func (s *UserService) SetUserIsActive(
 	return user, nil
 }
 
+// GetUserReviews возвращает PR, где пользователь ревьювер; непустой label — только PR с этой меткой.
 func (s *UserService) GetUserReviews(
 	ctx context.Context,
 	exec repository.DBExecutor,
-	userID string,
+	userID, label string,
 ) (string, []domain.PullRequest, error) {
-	prs, err := s.PRs.ListPRsByReviewer(ctx, exec, userID)
+	prs, err := s.PRs.ListPRsByReviewer(ctx, exec, userID, domain.NormalizeLabel(label))
 	if err != nil {
 		s.Logger.Error("user_get_reviews_failed", "user_id", userID, "err", err)
 		return "", nil, fmt.Errorf("get reviews for user %q: %w", userID, err)
@@ -54,3 +73,226 @@ func (s *UserService) GetUserReviews(
 
 	return userID, prs, nil
 }
+
+func (s *UserService) SetReviewCapacity(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	capacity *int,
+) (*domain.User, error) {
+	probe := domain.User{ID: userID}
+	if err := probe.SetReviewCapacity(capacity); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Users.SetReviewCapacity(ctx, exec, userID, capacity)
+	if err != nil {
+		s.Logger.Error("user_set_review_capacity_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set review capacity for user %q: %w", userID, err)
+	}
+	return user, nil
+}
+
+func (s *UserService) SetSkills(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	skills []string,
+) (*domain.User, error) {
+	user, err := s.Users.SetSkills(ctx, exec, userID, domain.NormalizeSkills(skills))
+	if err != nil {
+		s.Logger.Error("user_set_skills_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set skills for user %q: %w", userID, err)
+	}
+	return user, nil
+}
+
+func (s *UserService) SetSchedule(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID, timeZone string,
+	wh *domain.WorkingHours,
+) (*domain.User, error) {
+	probe := domain.User{ID: userID}
+	if err := probe.SetSchedule(timeZone, wh); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Users.SetSchedule(ctx, exec, userID, probe.TimeZone, probe.WorkingHours)
+	if err != nil {
+		s.Logger.Error("user_set_schedule_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set schedule for user %q: %w", userID, err)
+	}
+	return user, nil
+}
+
+func (s *UserService) SetSeniority(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID, level string,
+) (*domain.User, error) {
+	seniority, err := domain.ParseSeniority(level)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.Users.SetSeniority(ctx, exec, userID, seniority)
+	if err != nil {
+		s.Logger.Error("user_set_seniority_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set seniority for user %q: %w", userID, err)
+	}
+	return user, nil
+}
+
+func (s *UserService) SetReviewWeight(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	weight int,
+) (*domain.User, error) {
+	probe := domain.User{ID: userID}
+	if err := probe.SetReviewWeight(weight); err != nil {
+		return nil, err
+	}
+
+	user, err := s.Users.SetReviewWeight(ctx, exec, userID, probe.ReviewWeight)
+	if err != nil {
+		s.Logger.Error("user_set_review_weight_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("set review weight for user %q: %w", userID, err)
+	}
+	return user, nil
+}
+
+func (s *UserService) GetUserLoad(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+) (*UserLoad, error) {
+	user, err := s.Users.GetUserByID(ctx, exec, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.Teams.GetTeamWithMembers(ctx, exec, user.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	load, err := s.PRs.CountOpenReviews(ctx, exec, []string{userID})
+	if err != nil {
+		s.Logger.Error("user_get_load_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("get load for user %q: %w", userID, err)
+	}
+
+	res := &UserLoad{
+		User:        user,
+		OpenReviews: load[userID],
+	}
+	if capacity, ok := team.Settings.ReviewCapacity(*user); ok {
+		res.Capacity = &capacity
+		res.CapacitySource = "team"
+		if user.ReviewCapacity != nil {
+			res.CapacitySource = "user"
+		}
+	}
+
+	return res, nil
+}
+
+func (s *UserService) AddUnavailability(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	startsAt, endsAt time.Time,
+	reason string,
+) (*domain.Unavailability, error) {
+	period, err := domain.NewUnavailability(userID, startsAt, endsAt, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Users.AddUnavailability(ctx, exec, period); err != nil {
+		s.Logger.Error("user_add_unavailability_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("add unavailability for user %q: %w", userID, err)
+	}
+	return period, nil
+}
+
+// ListUnavailability возвращает текущие и будущие периоды отсутствия пользователя.
+func (s *UserService) ListUnavailability(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	now time.Time,
+) ([]domain.Unavailability, error) {
+	if _, err := s.Users.GetUserByID(ctx, exec, userID); err != nil {
+		return nil, err
+	}
+
+	periods, err := s.Users.ListUnavailability(ctx, exec, userID, now)
+	if err != nil {
+		s.Logger.Error("user_list_unavailability_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("list unavailability for user %q: %w", userID, err)
+	}
+	return periods, nil
+}
+
+func (s *UserService) DeleteUnavailability(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+	id int64,
+) error {
+	if err := s.Users.DeleteUnavailability(ctx, exec, userID, id); err != nil {
+		s.Logger.Error("user_delete_unavailability_failed", "user_id", userID, "id", id, "err", err)
+		return fmt.Errorf("delete unavailability %d for user %q: %w", id, userID, err)
+	}
+	return nil
+}
+
+func (s *UserService) AddReviewerExclusion(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID, otherUserID, reason string,
+) (*domain.ReviewerExclusion, error) {
+	exclusion, err := domain.NewReviewerExclusion(userID, otherUserID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Users.AddReviewerExclusion(ctx, exec, exclusion); err != nil {
+		s.Logger.Error("user_add_reviewer_exclusion_failed", "user_id", userID, "other_user_id", otherUserID, "err", err)
+		return nil, fmt.Errorf("add reviewer exclusion for users %q and %q: %w", userID, otherUserID, err)
+	}
+	return exclusion, nil
+}
+
+// ListReviewerExclusions возвращает пары-исключения, в которые входит пользователь.
+func (s *UserService) ListReviewerExclusions(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID string,
+) ([]domain.ReviewerExclusion, error) {
+	if _, err := s.Users.GetUserByID(ctx, exec, userID); err != nil {
+		return nil, err
+	}
+
+	exclusions, err := s.Users.ListReviewerExclusions(ctx, exec, userID)
+	if err != nil {
+		s.Logger.Error("user_list_reviewer_exclusions_failed", "user_id", userID, "err", err)
+		return nil, fmt.Errorf("list reviewer exclusions for user %q: %w", userID, err)
+	}
+	return exclusions, nil
+}
+
+func (s *UserService) DeleteReviewerExclusion(
+	ctx context.Context,
+	exec repository.DBExecutor,
+	userID, otherUserID string,
+) error {
+	if err := s.Users.DeleteReviewerExclusion(ctx, exec, userID, otherUserID); err != nil {
+		s.Logger.Error("user_delete_reviewer_exclusion_failed", "user_id", userID, "other_user_id", otherUserID, "err", err)
+		return fmt.Errorf("delete reviewer exclusion for users %q and %q: %w", userID, otherUserID, err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+DELETE FROM pr_reviewers WHERE slot > 1;
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_slot_check;
+ALTER TABLE pr_reviewers
+    ADD CONSTRAINT pr_reviewers_slot_check CHECK (slot IN (0,1));
+
+ALTER TABLE prs DROP COLUMN IF EXISTS reviewers_required;
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewers_count;
@@ -0,0 +1,11 @@
+ALTER TABLE teams
+    ADD COLUMN reviewers_count INT NOT NULL DEFAULT 2
+        CHECK (reviewers_count BETWEEN 1 AND 10);
+
+ALTER TABLE prs
+    ADD COLUMN reviewers_required INT NOT NULL DEFAULT 2
+        CHECK (reviewers_required BETWEEN 1 AND 10);
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_slot_check;
+ALTER TABLE pr_reviewers
+    ADD CONSTRAINT pr_reviewers_slot_check CHECK (slot BETWEEN 0 AND 9);
@@ -0,0 +1,5 @@
+ALTER TABLE teams
+    ADD COLUMN fallback_teams TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN fallback_team TEXT NULL REFERENCES teams(team_name) ON DELETE RESTRICT;
@@ -0,0 +1,5 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS capacity_policy,
+    DROP COLUMN IF EXISTS default_review_capacity;
+
+ALTER TABLE users DROP COLUMN IF EXISTS review_capacity;
@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN review_capacity INT NULL CHECK (review_capacity >= 0);
+
+ALTER TABLE teams
+    ADD COLUMN default_review_capacity INT NULL CHECK (default_review_capacity >= 0),
+    ADD COLUMN capacity_policy TEXT NOT NULL DEFAULT 'ASSIGN_ANYWAY'
+        CHECK (capacity_policy IN ('ASSIGN_ANYWAY', 'LEAVE_EMPTY', 'NO_CANDIDATE'));
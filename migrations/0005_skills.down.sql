@@ -0,0 +1,2 @@
+ALTER TABLE prs DROP COLUMN IF EXISTS required_skills;
+ALTER TABLE users DROP COLUMN IF EXISTS skills;
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN skills TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE prs
+    ADD COLUMN required_skills TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,4 @@
+ALTER TABLE prs
+    DROP COLUMN IF EXISTS changed_files;
+
+DROP TABLE IF EXISTS code_owner_rules;
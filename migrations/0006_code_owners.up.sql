@@ -0,0 +1,11 @@
+CREATE TABLE code_owner_rules (
+    id          BIGSERIAL PRIMARY KEY,
+    pattern     TEXT   NOT NULL,
+    owner_teams TEXT[] NOT NULL DEFAULT '{}',
+    owner_users TEXT[] NOT NULL DEFAULT '{}',
+    source      TEXT   NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'codeowners')),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE prs
+    ADD COLUMN changed_files TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN time_zone  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN work_start INT NULL CHECK (work_start >= 0 AND work_start < 1440),
+    ADD COLUMN work_end   INT NULL CHECK (work_end >= 0 AND work_end < 1440),
+    ADD CONSTRAINT users_working_hours_check CHECK ((work_start IS NULL) = (work_end IS NULL));
+
+ALTER TABLE teams
+    ADD COLUMN prefer_working_hours    BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN working_hours_lookahead INT NOT NULL DEFAULT 0 CHECK (working_hours_lookahead BETWEEN 0 AND 24);
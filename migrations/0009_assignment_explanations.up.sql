@@ -0,0 +1,15 @@
+CREATE TABLE pr_assignment_explanations (
+    id                BIGSERIAL PRIMARY KEY,
+    pr_id             TEXT   NOT NULL REFERENCES prs(pr_id) ON DELETE CASCADE,
+    kind              TEXT   NOT NULL CHECK (kind IN ('CREATE', 'REASSIGN')),
+    strategy          TEXT   NOT NULL,
+    seed              BIGINT NOT NULL,
+    reviewers         TEXT[] NOT NULL DEFAULT '{}',
+    replaced_reviewer TEXT,
+    rounds            JSONB  NOT NULL DEFAULT '[]',
+    excluded          JSONB  NOT NULL DEFAULT '[]',
+    reason            TEXT   NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_assignment_explanations_pr ON pr_assignment_explanations(pr_id, id);
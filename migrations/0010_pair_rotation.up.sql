@@ -0,0 +1,2 @@
+ALTER TABLE teams
+    ADD COLUMN pair_rotation_window_days INT NOT NULL DEFAULT 30 CHECK (pair_rotation_window_days BETWEEN 1 AND 365);
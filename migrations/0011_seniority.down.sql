@@ -0,0 +1,7 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS seniority_fallback,
+    DROP COLUMN IF EXISTS max_junior_reviewers,
+    DROP COLUMN IF EXISTS min_senior_reviewers;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS seniority;
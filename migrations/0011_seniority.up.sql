@@ -0,0 +1,7 @@
+ALTER TABLE users
+    ADD COLUMN seniority TEXT NOT NULL DEFAULT '' CHECK (seniority IN ('', 'junior', 'middle', 'senior', 'lead'));
+
+ALTER TABLE teams
+    ADD COLUMN min_senior_reviewers INT NOT NULL DEFAULT 0 CHECK (min_senior_reviewers >= 0),
+    ADD COLUMN max_junior_reviewers INT NULL CHECK (max_junior_reviewers >= 0),
+    ADD COLUMN seniority_fallback TEXT NOT NULL DEFAULT 'REJECT' CHECK (seniority_fallback IN ('REJECT', 'IGNORE', 'LEAVE_EMPTY'));
@@ -0,0 +1,4 @@
+ALTER TABLE prs
+    DROP COLUMN IF EXISTS excluded_reviewers;
+
+DROP TABLE IF EXISTS reviewer_exclusions;
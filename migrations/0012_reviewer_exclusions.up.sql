@@ -0,0 +1,14 @@
+CREATE TABLE reviewer_exclusions (
+    user_id       TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    other_user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    reason        TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, other_user_id),
+    -- пара хранится один раз; порядок байтовый, как в domain.NewReviewerExclusion
+    CHECK (user_id < other_user_id COLLATE "C")
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_exclusions_other ON reviewer_exclusions(other_user_id);
+
+ALTER TABLE prs
+    ADD COLUMN excluded_reviewers TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN review_weight INT NOT NULL DEFAULT 100 CHECK (review_weight BETWEEN 0 AND 100);
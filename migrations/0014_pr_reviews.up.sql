@@ -0,0 +1,10 @@
+CREATE TABLE pr_reviews (
+    id           BIGSERIAL PRIMARY KEY,
+    pr_id        TEXT NOT NULL REFERENCES prs(pr_id) ON DELETE CASCADE,
+    user_id      TEXT NOT NULL REFERENCES users(user_id) ON DELETE RESTRICT,
+    verdict      TEXT NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    message      TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviews_pr_user ON pr_reviews(pr_id, user_id, id);
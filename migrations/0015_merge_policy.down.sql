@@ -0,0 +1,6 @@
+ALTER TABLE pr_events
+    DROP COLUMN IF EXISTS details;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS block_on_changes_requested,
+    DROP COLUMN IF EXISTS min_approvals;
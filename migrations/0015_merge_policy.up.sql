@@ -0,0 +1,6 @@
+ALTER TABLE teams
+    ADD COLUMN min_approvals INT NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    ADD COLUMN block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE pr_events
+    ADD COLUMN details TEXT NOT NULL DEFAULT '';
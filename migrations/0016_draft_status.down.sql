@@ -0,0 +1,9 @@
+-- значения enum удалить нельзя: пересоздаём тип; DRAFT становится OPEN
+ALTER TABLE prs ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE prs ALTER COLUMN status TYPE TEXT;
+UPDATE prs SET status = 'OPEN' WHERE status = 'DRAFT';
+
+DROP TYPE pr_status;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+ALTER TABLE prs ALTER COLUMN status TYPE pr_status USING status::pr_status;
+ALTER TABLE prs ALTER COLUMN status SET DEFAULT 'OPEN';
@@ -0,0 +1,2 @@
+-- ADD VALUE — отдельной миграцией: новое значение enum нельзя использовать в той же транзакции
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'DRAFT';
@@ -0,0 +1,10 @@
+-- значения enum удалить нельзя: пересоздаём тип; закрытые PR становятся конечными MERGED,
+-- а не OPEN без ревьюверов (closed_at к этому моменту уже удалён откатом 0018)
+ALTER TABLE prs ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE prs ALTER COLUMN status TYPE TEXT;
+UPDATE prs SET status = 'MERGED', merged_at = COALESCE(merged_at, now()) WHERE status = 'CLOSED';
+
+DROP TYPE pr_status;
+CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED', 'DRAFT');
+ALTER TABLE prs ALTER COLUMN status TYPE pr_status USING status::pr_status;
+ALTER TABLE prs ALTER COLUMN status SET DEFAULT 'OPEN';
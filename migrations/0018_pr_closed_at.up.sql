@@ -0,0 +1,2 @@
+ALTER TABLE prs
+    ADD COLUMN closed_at TIMESTAMPTZ NULL;
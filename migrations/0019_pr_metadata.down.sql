@@ -0,0 +1,13 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS large_pr_extra_reviewers,
+    DROP COLUMN IF EXISTS large_pr_lines;
+
+ALTER TABLE prs
+    DROP COLUMN IF EXISTS files_changed,
+    DROP COLUMN IF EXISTS lines_deleted,
+    DROP COLUMN IF EXISTS lines_added,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS url,
+    DROP COLUMN IF EXISTS target_branch,
+    DROP COLUMN IF EXISTS source_branch,
+    DROP COLUMN IF EXISTS repository;
@@ -0,0 +1,13 @@
+ALTER TABLE prs
+    ADD COLUMN repository    TEXT NOT NULL DEFAULT '',
+    ADD COLUMN source_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN target_branch TEXT NOT NULL DEFAULT '',
+    ADD COLUMN url           TEXT NOT NULL DEFAULT '',
+    ADD COLUMN description   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN lines_added   INT  NOT NULL DEFAULT 0 CHECK (lines_added >= 0),
+    ADD COLUMN lines_deleted INT  NOT NULL DEFAULT 0 CHECK (lines_deleted >= 0),
+    ADD COLUMN files_changed INT  NOT NULL DEFAULT 0 CHECK (files_changed >= 0);
+
+ALTER TABLE teams
+    ADD COLUMN large_pr_lines           INT NOT NULL DEFAULT 0 CHECK (large_pr_lines >= 0),
+    ADD COLUMN large_pr_extra_reviewers INT NOT NULL DEFAULT 1 CHECK (large_pr_extra_reviewers >= 0);
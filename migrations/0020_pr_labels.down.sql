@@ -0,0 +1,4 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS label_rules;
+
+DROP TABLE IF EXISTS pr_labels;
@@ -0,0 +1,10 @@
+CREATE TABLE pr_labels (
+    pr_id TEXT NOT NULL REFERENCES prs(pr_id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (pr_id, label)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_labels_label ON pr_labels(label, pr_id);
+
+ALTER TABLE teams
+    ADD COLUMN label_rules JSONB NOT NULL DEFAULT '[]';
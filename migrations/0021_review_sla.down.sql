@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_due_at;
+
+ALTER TABLE pr_reviewers
+    DROP COLUMN IF EXISTS due_at;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS review_sla_hours;
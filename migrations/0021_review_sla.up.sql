@@ -0,0 +1,8 @@
+ALTER TABLE teams
+    ADD COLUMN review_sla_hours INT NOT NULL DEFAULT 0 CHECK (review_sla_hours >= 0);
+
+-- срок ревью по SLA команды автора; NULL — SLA не задан
+ALTER TABLE pr_reviewers
+    ADD COLUMN due_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_due_at ON pr_reviewers(due_at) WHERE due_at IS NOT NULL;
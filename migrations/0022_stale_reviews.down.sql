@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_assigned_at;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS stale_review_hours;
@@ -0,0 +1,4 @@
+ALTER TABLE teams
+    ADD COLUMN stale_review_hours INT NOT NULL DEFAULT 0 CHECK (stale_review_hours >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_assigned_at ON pr_reviewers(assigned_at);
@@ -0,0 +1,2 @@
+ALTER TABLE prs
+    DROP COLUMN IF EXISTS priority;
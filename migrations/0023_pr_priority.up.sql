@@ -0,0 +1,3 @@
+ALTER TABLE prs
+    ADD COLUMN priority TEXT NOT NULL DEFAULT 'normal'
+        CHECK (priority IN ('low', 'normal', 'high', 'hotfix'));
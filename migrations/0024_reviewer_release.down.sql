@@ -0,0 +1,11 @@
+-- история снятых ревьюверов теряется
+DELETE FROM pr_reviewers WHERE released_at IS NOT NULL;
+
+DROP INDEX IF EXISTS uq_pr_reviewers_active_user;
+DROP INDEX IF EXISTS uq_pr_reviewers_active_slot;
+
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS id;
+ALTER TABLE pr_reviewers ADD PRIMARY KEY (pr_id, slot);
+ALTER TABLE pr_reviewers ADD CONSTRAINT pr_reviewers_pr_id_user_id_key UNIQUE (pr_id, user_id);
+
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS released_at;
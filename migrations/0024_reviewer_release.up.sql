@@ -0,0 +1,12 @@
+-- снятые и заменённые ревьюверы остаются в истории назначений с released_at;
+-- слот и ревьювер уникальны только среди текущих назначений PR
+ALTER TABLE pr_reviewers
+    ADD COLUMN released_at TIMESTAMPTZ NULL;
+
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pkey;
+ALTER TABLE pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_pr_id_user_id_key;
+ALTER TABLE pr_reviewers
+    ADD COLUMN id BIGSERIAL PRIMARY KEY;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_pr_reviewers_active_slot ON pr_reviewers(pr_id, slot) WHERE released_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_pr_reviewers_active_user ON pr_reviewers(pr_id, user_id) WHERE released_at IS NULL;